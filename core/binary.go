@@ -0,0 +1,237 @@
+package core
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// BinaryInfo describes a compiled executable or library, read from its
+// headers without running it
+type BinaryInfo struct {
+	Format       string   // ELF, PE or Mach-O
+	Kind         string   // executable, shared library, object, ...
+	Architecture string   // Target architecture(s), GOARCH-style where known
+	Libraries    []string // Dynamically linked libraries
+	Stripped     bool     // Whether symbols and debug info were removed
+
+	// Go binaries only
+	GoVersion string   // Toolchain the binary was built with
+	GoModule  string   // Main module path and version
+	GoDeps    []string // Module dependencies with versions
+}
+
+// Magic numbers of supported executable formats
+var (
+	elfMagic   = []byte("\x7fELF")
+	peMagic    = []byte("MZ")
+	machoMagic = [][]byte{
+		{0xfe, 0xed, 0xfa, 0xce}, {0xce, 0xfa, 0xed, 0xfe}, // 32-bit
+		{0xfe, 0xed, 0xfa, 0xcf}, {0xcf, 0xfa, 0xed, 0xfe}, // 64-bit
+	}
+	machoFatMagic = []byte{0xca, 0xfe, 0xba, 0xbe}
+)
+
+// Mach-O nlist type bits: debugger (stab) entries and external symbols
+const (
+	machoStabMask = 0xe0
+	machoExternal = 0x01
+)
+
+// elfArchitectures maps ELF machine types to GOARCH-style names
+var elfArchitectures = map[elf.Machine]string{
+	elf.EM_386:     "386",
+	elf.EM_X86_64:  "amd64",
+	elf.EM_ARM:     "arm",
+	elf.EM_AARCH64: "arm64",
+	elf.EM_RISCV:   "riscv64",
+	elf.EM_PPC64:   "ppc64",
+	elf.EM_S390:    "s390x",
+	elf.EM_MIPS:    "mips",
+}
+
+// peArchitectures maps PE machine types to GOARCH-style names
+var peArchitectures = map[uint16]string{
+	pe.IMAGE_FILE_MACHINE_I386:  "386",
+	pe.IMAGE_FILE_MACHINE_AMD64: "amd64",
+	pe.IMAGE_FILE_MACHINE_ARMNT: "arm",
+	pe.IMAGE_FILE_MACHINE_ARM64: "arm64",
+}
+
+// isBinaryExecutable reports whether head starts with an ELF, PE or Mach-O header
+func isBinaryExecutable(head []byte) bool {
+	if bytes.HasPrefix(head, elfMagic) || bytes.HasPrefix(head, peMagic) {
+		return true
+	}
+	for _, magic := range machoMagic {
+		if bytes.HasPrefix(head, magic) {
+			return true
+		}
+	}
+	// Java class files share the fat Mach-O magic; their version field is
+	// far larger than any realistic architecture count
+	if bytes.HasPrefix(head, machoFatMagic) && len(head) >= 8 {
+		return binary.BigEndian.Uint32(head[4:8]) < 20
+	}
+	return false
+}
+
+// parseExecutable statically inspects ELF, PE and Mach-O binaries. The file is
+// never executed; help output is only collected on request.
+func (s *Summarizer) parseExecutable(fullPath string, summary FileSummary) FileSummary {
+	info, err := inspectBinary(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error inspecting executable: %v", err)
+		return summary
+	}
+
+	if goInfo, err := buildinfo.ReadFile(fullPath); err == nil {
+		info.GoVersion = goInfo.GoVersion
+		info.GoModule = strings.TrimSpace(goInfo.Main.Path + " " + goInfo.Main.Version)
+		for _, dep := range goInfo.Deps {
+			entry := dep.Path + " " + dep.Version
+			if dep.Replace != nil {
+				entry += " => " + strings.TrimSpace(dep.Replace.Path+" "+dep.Replace.Version)
+			}
+			info.GoDeps = append(info.GoDeps, entry)
+		}
+		summary.Language = "Go binary"
+	}
+
+	summary.Binary = info
+	return summary
+}
+
+// inspectBinary reads the headers of an executable in any supported format
+func inspectBinary(fullPath string) (*BinaryInfo, error) {
+	if file, err := elf.Open(fullPath); err == nil {
+		defer file.Close()
+		return inspectELF(file), nil
+	}
+	if file, err := pe.Open(fullPath); err == nil {
+		defer file.Close()
+		return inspectPE(file), nil
+	}
+	if file, err := macho.Open(fullPath); err == nil {
+		defer file.Close()
+		return inspectMachO(file), nil
+	}
+	if file, err := macho.OpenFat(fullPath); err == nil {
+		defer file.Close()
+		return inspectFatMachO(file), nil
+	}
+	return nil, fmt.Errorf("not an ELF, PE or Mach-O file")
+}
+
+// inspectELF summarizes a Linux/BSD ELF binary
+func inspectELF(file *elf.File) *BinaryInfo {
+	info := &BinaryInfo{Format: "ELF"}
+
+	info.Architecture = elfArchitectures[file.Machine]
+	if info.Architecture == "" {
+		info.Architecture = strings.TrimPrefix(file.Machine.String(), "EM_")
+	}
+
+	interpreted := false
+	for _, prog := range file.Progs {
+		if prog.Type == elf.PT_INTERP {
+			interpreted = true
+		}
+	}
+	switch file.Type {
+	case elf.ET_EXEC:
+		info.Kind = "executable"
+	case elf.ET_DYN:
+		// Position-independent executables are shared objects with an interpreter
+		info.Kind = "shared library"
+		if interpreted {
+			info.Kind = "executable (PIE)"
+		}
+	case elf.ET_REL:
+		info.Kind = "object"
+	case elf.ET_CORE:
+		info.Kind = "core dump"
+	}
+
+	info.Libraries, _ = file.ImportedLibraries()
+	info.Stripped = file.Section(".symtab") == nil
+	return info
+}
+
+// inspectPE summarizes a Windows PE binary
+func inspectPE(file *pe.File) *BinaryInfo {
+	info := &BinaryInfo{Format: "PE", Kind: "executable"}
+
+	info.Architecture = peArchitectures[file.Machine]
+	if info.Architecture == "" {
+		info.Architecture = fmt.Sprintf("machine 0x%x", file.Machine)
+	}
+	if file.Characteristics&pe.IMAGE_FILE_DLL != 0 {
+		info.Kind = "DLL"
+	}
+
+	// debug/pe does not implement ImportedLibraries; imported symbols are
+	// reported as "function:library"
+	symbols, _ := file.ImportedSymbols()
+	seen := make(map[string]bool)
+	for _, symbol := range symbols {
+		if _, library, ok := strings.Cut(symbol, ":"); ok && !seen[strings.ToLower(library)] {
+			seen[strings.ToLower(library)] = true
+			info.Libraries = append(info.Libraries, library)
+		}
+	}
+	info.Stripped = file.NumberOfSymbols == 0 && file.Section(".debug_info") == nil && file.Section(".zdebug_info") == nil
+	return info
+}
+
+// inspectMachO summarizes a single-architecture macOS binary
+func inspectMachO(file *macho.File) *BinaryInfo {
+	info := &BinaryInfo{Format: "Mach-O"}
+
+	info.Architecture = strings.ToLower(strings.TrimPrefix(file.Cpu.String(), "Cpu"))
+	switch file.Type {
+	case macho.TypeExec:
+		info.Kind = "executable"
+	case macho.TypeDylib:
+		info.Kind = "shared library"
+	case macho.TypeBundle:
+		info.Kind = "bundle"
+	case macho.TypeObj:
+		info.Kind = "object"
+	}
+
+	info.Libraries, _ = file.ImportedLibraries()
+	// strip(1) removes local symbols, leaving only external ones
+	info.Stripped = true
+	if file.Symtab != nil {
+		for _, sym := range file.Symtab.Syms {
+			if sym.Type&machoStabMask == 0 && sym.Type&machoExternal == 0 {
+				info.Stripped = false
+				break
+			}
+		}
+	}
+	return info
+}
+
+// inspectFatMachO summarizes a universal binary from its first architecture,
+// listing every architecture it contains
+func inspectFatMachO(file *macho.FatFile) *BinaryInfo {
+	if len(file.Arches) == 0 {
+		return &BinaryInfo{Format: "Mach-O (universal)"}
+	}
+
+	info := inspectMachO(file.Arches[0].File)
+	info.Format = "Mach-O (universal)"
+	var architectures []string
+	for _, arch := range file.Arches {
+		architectures = append(architectures, strings.ToLower(strings.TrimPrefix(arch.Cpu.String(), "Cpu")))
+	}
+	info.Architecture = strings.Join(architectures, ", ")
+	return info
+}
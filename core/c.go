@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// C declaration patterns, applied to comment-stripped source
+var (
+	cIncludePattern     = regexp.MustCompile(`^\s*#\s*include\s*([<"][^>"]+[>"])`)
+	cDefinePattern      = regexp.MustCompile(`^\s*#\s*define\s+(\w+(?:\([^)]*\))?)`)
+	cTagPattern         = regexp.MustCompile(`\b(struct|union|enum)\s+(\w+)\s*\{`)
+	cFuncPointerPattern = regexp.MustCompile(`\(\s*\*\s*(\w+)\s*\)`)
+	cFunctionPattern    = regexp.MustCompile(`^(.*?)\b(\w+)\s*\(([^*].*)?\)\s*(?:__attribute__\s*\(\(.*\)\))?$`)
+	cDeclaratorPattern  = regexp.MustCompile(`(\w+)\s*(?:\[[^\]]*\]\s*)*(?:=.*)?$`)
+	cExternBlockPattern = regexp.MustCompile(`^extern\s+""$`)
+	cBareTagPattern     = regexp.MustCompile(`^(?:struct|union|enum)\s*\w*$`)
+)
+
+// cKeywords cannot be the name of a function or variable
+var cKeywords = map[string]bool{
+	"if": true, "while": true, "for": true, "switch": true, "return": true, "sizeof": true,
+	"int": true, "char": true, "void": true, "long": true, "short": true, "unsigned": true,
+	"signed": true, "float": true, "double": true, "struct": true, "union": true, "enum": true,
+}
+
+// parseCFile extracts includes, macros, type declarations, function definitions
+// and prototypes from C sources and headers
+func (s *Summarizer) parseCFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	original := strings.Split(string(content), "\n")
+	stripped := strings.Split(stripCComments(string(content)), "\n")
+	summary.LineCount = len(original)
+
+	// Preprocessor directives are handled line by line and removed from the
+	// code that is scanned for declarations
+	inDirective := false
+	for i, line := range stripped {
+		trimmed := strings.TrimSpace(line)
+		if inDirective || strings.HasPrefix(trimmed, "#") {
+			if !inDirective {
+				if matches := cIncludePattern.FindStringSubmatch(original[i]); matches != nil {
+					summary.Imports = append(summary.Imports, matches[1])
+				} else if matches := cDefinePattern.FindStringSubmatch(line); matches != nil {
+					summary.Macros = append(summary.Macros, matches[1])
+				}
+			}
+			inDirective = strings.HasSuffix(trimmed, "\\")
+			stripped[i] = ""
+		}
+	}
+
+	code := strings.Join(stripped, "\n")
+	var stmt strings.Builder
+	depth := 0
+	inFunctionBody := false
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		if depth > 0 {
+			switch c {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					if inFunctionBody {
+						inFunctionBody = false
+						stmt.Reset()
+					} else {
+						stmt.WriteString("}")
+					}
+				}
+			}
+			continue
+		}
+
+		switch c {
+		case '{':
+			header := normalizeSpace(stmt.String())
+			if cExternBlockPattern.MatchString(header) {
+				// extern "C" { ... } wraps declarations without nesting them
+				stmt.Reset()
+				continue
+			}
+			if name, static, ok := cFunctionHeader(header); ok {
+				summary.Functions = append(summary.Functions, cLinkage(static)+name)
+				summary.FunctionCount++
+				inFunctionBody = true
+			} else {
+				stmt.WriteString("{")
+			}
+			depth++
+		case '}':
+			// Closing brace of an extern "C" block
+		case ';':
+			addCDeclaration(&summary, normalizeSpace(stmt.String()))
+			stmt.Reset()
+		default:
+			stmt.WriteByte(c)
+		}
+	}
+
+	return summary
+}
+
+// addCDeclaration classifies a top-level statement terminated by a semicolon
+func addCDeclaration(summary *FileSummary, decl string) {
+	if decl == "" {
+		return
+	}
+
+	// Record tagged struct/union/enum definitions, including ones inside typedefs
+	tags := cTagPattern.FindAllStringSubmatch(decl, -1)
+	for _, matches := range tags {
+		entry := fmt.Sprintf("%s %s", matches[1], matches[2])
+		summary.Types = append(summary.Types, entry)
+		if matches[1] == "struct" {
+			summary.Structs = append(summary.Structs, matches[2])
+		}
+	}
+
+	if strings.HasPrefix(decl, "typedef ") {
+		name := ""
+		if matches := cFuncPointerPattern.FindStringSubmatch(decl); matches != nil {
+			name = matches[1]
+		} else if matches := cDeclaratorPattern.FindStringSubmatch(decl); matches != nil {
+			name = matches[1]
+		}
+		if name != "" {
+			summary.Types = append(summary.Types, name+" (typedef)")
+			if len(tags) == 0 && strings.HasPrefix(decl, "typedef struct") && strings.Contains(decl, "{") {
+				summary.Structs = append(summary.Structs, name)
+			}
+		}
+		return
+	}
+
+	// Bare type definitions and forward declarations carry no other names
+	if (len(tags) > 0 && strings.HasSuffix(decl, "}")) || cBareTagPattern.MatchString(decl) {
+		return
+	}
+
+	if name, static, ok := cFunctionHeader(decl); ok {
+		summary.Prototypes = append(summary.Prototypes, cLinkage(static)+name)
+		return
+	}
+
+	if strings.Contains(decl, "(") && !strings.Contains(decl, "=") && !cFuncPointerPattern.MatchString(decl) {
+		// Top-level macro invocations such as MODULE_LICENSE(...)
+		return
+	}
+
+	name := ""
+	if matches := cFuncPointerPattern.FindStringSubmatch(decl); matches != nil {
+		name = matches[1]
+	} else {
+		first := decl
+		if idx := strings.Index(first, ","); idx >= 0 {
+			first = first[:idx]
+		}
+		if matches := cDeclaratorPattern.FindStringSubmatch(first); matches != nil {
+			name = matches[1]
+		}
+	}
+	if name != "" && !cKeywords[name] && strings.Contains(strings.TrimSpace(decl), " ") {
+		summary.Variables = append(summary.Variables, cLinkage(strings.HasPrefix(decl, "static "))+name)
+	}
+}
+
+// cFunctionHeader recognizes "type name(params)" and reports the function name
+// and whether it has internal (static) linkage
+func cFunctionHeader(header string) (name string, static bool, ok bool) {
+	if header == "" || strings.Contains(header, "=") || strings.HasPrefix(header, "typedef ") {
+		return "", false, false
+	}
+	if cBareTagPattern.MatchString(header) {
+		return "", false, false
+	}
+
+	matches := cFunctionPattern.FindStringSubmatch(header)
+	if matches == nil || strings.TrimSpace(matches[1]) == "" || cKeywords[matches[2]] {
+		return "", false, false
+	}
+	return matches[2], strings.Contains(" "+matches[1], " static "), true
+}
+
+// cLinkage marks external linkage with + and static (file-local) linkage with -
+func cLinkage(static bool) string {
+	if static {
+		return "- "
+	}
+	return "+ "
+}
+
+// normalizeSpace collapses runs of whitespace into single spaces
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stripCComments blanks out // and /* */ comments and the contents of string
+// and character literals so brace counting and line patterns are not confused
+// by them. Newlines are preserved so line numbers stay the same.
+func stripCComments(content string) string {
+	return stripComments(content, true, false)
+}
+
+// stripComments is stripCComments with a choice of comment syntax: slashComments
+// enables // and /* */, hashComments enables # line comments (except the #[
+// that starts a PHP attribute)
+func stripComments(content string, slashComments, hashComments bool) string {
+	var result strings.Builder
+	result.Grow(len(content))
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case (slashComments && c == '/' && i+1 < len(content) && content[i+1] == '/') ||
+			(hashComments && c == '#' && !(i+1 < len(content) && content[i+1] == '[')):
+			// Line comment: skip to the end of the line
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				result.WriteByte('\n')
+			}
+
+		case slashComments && c == '/' && i+1 < len(content) && content[i+1] == '*':
+			// Block comment: keep only the newlines
+			i += 2
+			for i < len(content) && !(content[i] == '*' && i+1 < len(content) && content[i+1] == '/') {
+				if content[i] == '\n' {
+					result.WriteByte('\n')
+				}
+				i++
+			}
+			i++
+			result.WriteByte(' ')
+
+		case c == '"' || c == '\'':
+			// String or character literal: keep the quotes, drop the contents
+			result.WriteByte(c)
+			i++
+			for i < len(content) && content[i] != c {
+				if content[i] == '\\' {
+					i++
+				} else if content[i] == '\n' {
+					result.WriteByte('\n')
+				}
+				i++
+			}
+			if i < len(content) {
+				result.WriteByte(c)
+			}
+
+		default:
+			result.WriteByte(c)
+		}
+	}
+
+	return result.String()
+}
+
+// braceDelta returns the change in brace depth caused by a line
+func braceDelta(line string) int {
+	return strings.Count(line, "{") - strings.Count(line, "}")
+}
+
+// accessMarker turns an access modifier into a UML-style visibility marker:
+// + public, - private, # protected and ~ package/internal
+func accessMarker(modifiers string, fallback string) string {
+	fields := strings.Fields(modifiers)
+	for _, field := range fields {
+		// Scala's qualified access, e.g. private[pkg]
+		if index := strings.Index(field, "["); index > 0 {
+			field = field[:index]
+		}
+		switch field {
+		case "public", "open":
+			return "+ "
+		case "private", "fileprivate":
+			return "- "
+		case "protected":
+			return "# "
+		case "internal":
+			return "~ "
+		}
+	}
+	return fallback
+}
+
+// typeScope tracks a type declaration while scanning brace-delimited source
+type typeScope struct {
+	name   string
+	kind   string
+	depth  int  // Brace depth at which the declaration appears
+	opened bool // Whether the body's opening brace has been seen
+}
+
+// scopeStack is a stack of enclosing type declarations
+type scopeStack []typeScope
+
+// current returns the innermost enclosing type, if any
+func (st scopeStack) current() (typeScope, bool) {
+	if len(st) == 0 {
+		return typeScope{}, false
+	}
+	return st[len(st)-1], true
+}
+
+// qualifiedName joins the enclosing type names with a new name
+func (st scopeStack) qualifiedName(name string) string {
+	if len(st) == 0 {
+		return name
+	}
+	return st[len(st)-1].name + "." + name
+}
+
+// push records a type declared at depth; opened says whether its body
+// brace is on the declaration line
+func (st scopeStack) push(name, kind string, depth int, opened bool) scopeStack {
+	return append(st, typeScope{name: name, kind: kind, depth: depth, opened: opened})
+}
+
+// update marks bodies opened at the new depth and pops types whose bodies closed
+func (st scopeStack) update(depth int) scopeStack {
+	for i := range st {
+		if depth > st[i].depth {
+			st[i].opened = true
+		}
+	}
+	for len(st) > 0 {
+		top := st[len(st)-1]
+		if !top.opened || depth > top.depth {
+			break
+		}
+		st = st[:len(st)-1]
+	}
+	return st
+}
+
+// bodyMembers returns the declarations inside a body opened on the same line
+// as its type, split at top-level semicolons and closing braces, e.g.
+// ["void a() {}", "int b;"] for "class C { void a() {} int b; }"
+func bodyMembers(line string) []string {
+	open := strings.Index(line, "{")
+	if open < 0 {
+		return nil
+	}
+
+	var members []string
+	add := func(member string) {
+		if member = strings.TrimSpace(member); member != "" {
+			members = append(members, member)
+		}
+	}
+
+	depth, start := 0, open+1
+	for i := open + 1; i < len(line); i++ {
+		switch line[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				// The type's own closing brace
+				add(line[start:i])
+				return members
+			}
+			depth--
+			if depth == 0 {
+				add(line[start : i+1])
+				start = i + 1
+			}
+		case ';':
+			if depth == 0 {
+				add(line[start : i+1])
+				start = i + 1
+			}
+		}
+	}
+	add(line[start:])
+	return members
+}
+
+// formatCounts renders a name→count map in first-seen order, e.g. "@Override (3)"
+func formatCounts(order []string, counts map[string]int) []string {
+	result := make([]string, 0, len(order))
+	for _, name := range order {
+		if counts[name] > 1 {
+			result = append(result, fmt.Sprintf("%s (%d)", name, counts[name]))
+		} else {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// declarationHasBody reports whether the declaration starting at lines[i] is
+// followed by a { body, looking past multi-line parameter lists such as a
+// Kotlin primary constructor. Declarations like "data class P(val x: Int)"
+// have no body and must not be pushed as a scope.
+func declarationHasBody(lines []string, i int) bool {
+	parens := 0
+	for j := i; j < len(lines) && j < i+20; j++ {
+		line := strings.TrimSpace(lines[j])
+		if j > i && line == "" {
+			continue
+		}
+		if j > i && parens <= 0 {
+			// Allman-style brace on the line after the declaration
+			return strings.HasPrefix(line, "{")
+		}
+		parens += strings.Count(line, "(") - strings.Count(line, ")")
+		if strings.Contains(line, "{") {
+			return true
+		}
+		if parens <= 0 && strings.HasSuffix(line, ";") {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBodyMembers(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"class C {", nil},
+		{"class C", nil},
+		{"class C { void a() {} }", []string{"void a() {}"}},
+		{"class C { void a() {} int b; }", []string{"void a() {}", "int b;"}},
+		{"protocol P { func p() }", []string{"func p()"}},
+		{"object O { fun o() = 1 }", []string{"fun o() = 1"}},
+		{"class C { int X { get; set; } }", []string{"int X { get; set; }"}},
+		{"class C { void a() {", []string{"void a() {"}},
+	}
+
+	for _, tt := range tests {
+		if got := bodyMembers(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bodyMembers(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestOneLineTypeBodies(t *testing.T) {
+	tests := []struct {
+		file    string
+		content string
+		members map[string][]string
+	}{
+		{
+			file:    "A.java",
+			content: "class A {\n    record R(int x) { void m() {} }\n    class C { void M() {} }\n}\n",
+			members: map[string][]string{"A.R": {"~ m"}, "A.C": {"~ M"}},
+		},
+		{
+			file:    "a.cs",
+			content: "class C { void M() {} public int X { get; set; } }\n",
+			members: map[string][]string{"C": {"- M", "+ X (property)"}},
+		},
+		{
+			file:    "a.swift",
+			content: "struct S { func f() {} }\nprotocol P { func p() }\n",
+			members: map[string][]string{"S": {"~ f"}, "P": {"+ p"}},
+		},
+		{
+			file:    "a.kt",
+			content: "object O { fun o() = 1 }\nclass K {\n    companion object { fun c() }\n}\n",
+			members: map[string][]string{"O": {"+ o"}, "K.Companion": {"+ c"}},
+		},
+		{
+			file:    "a.php",
+			content: "<?php\ntrait T { function t() {} }\n",
+			members: map[string][]string{"T": {"+ t"}},
+		},
+		{
+			file:    "a.scala",
+			content: "object O { def o = 1 }\n",
+			members: map[string][]string{"O": {"+ o"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			summary := summarizeContent(t, tt.file, tt.content)
+			if !reflect.DeepEqual(summary.Members, tt.members) {
+				t.Errorf("Members = %q, want %q", summary.Members, tt.members)
+			}
+		})
+	}
+}
+
+func TestSwiftClassModifier(t *testing.T) {
+	content := "open class View: UIView {\n    override class var layerClass: AnyClass { CAGradientLayer.self }\n    class func make() -> View { View() }\n}\n"
+	summary := summarizeContent(t, "View.swift", content)
+	assertStrings(t, "Types", summary.Types, []string{"+ View (class)"})
+	assertStrings(t, "Members", summary.Members["View"], []string{"~ make"})
+}
+
+func TestPHPTraitUse(t *testing.T) {
+	content := "<?php\nclass User {\n    use HasName, HasEmail;\n    public function name() {}\n}\n"
+	summary := summarizeContent(t, "User.php", content)
+	assertStrings(t, "Types", summary.Types, []string{"User (class, uses HasName, HasEmail)"})
+	assertStrings(t, "Members", summary.Members["User"], []string{"+ name"})
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// csharpModifiers is the alternation of C# declaration modifiers
+const csharpModifiers = `public|private|protected|internal|static|abstract|sealed|virtual|override|async|partial|readonly|new|unsafe|extern|required|file`
+
+// C# declaration patterns, applied to comment-stripped lines
+var (
+	csharpUsingPattern       = regexp.MustCompile(`^(global\s+)?using\s+(static\s+)?(?:(\w+)\s*=\s*)?([\w.]+(?:<[^>]*>)?)\s*;`)
+	csharpNamespacePattern   = regexp.MustCompile(`^namespace\s+([\w.]+)\s*(;)?`)
+	csharpAttributePattern   = regexp.MustCompile(`^\[([^\]]*)\]\s*`)
+	csharpAttributeName      = regexp.MustCompile(`(?:^|,)\s*(?:\w+\s*:\s*)?([A-Za-z_][\w.]*)`)
+	csharpArgumentsPattern   = regexp.MustCompile(`\([^)]*\)`)
+	csharpTypePattern        = regexp.MustCompile(`^((?:(?:` + csharpModifiers + `)\s+)*)(class|struct|interface|enum|record\s+struct|record\s+class|record)\s+(\w+)`)
+	csharpDelegatePattern    = regexp.MustCompile(`^((?:(?:` + csharpModifiers + `)\s+)*)delegate\s+.+?\s+(\w+)\s*(?:<[^>]*>)?\s*\(`)
+	csharpConstructorPattern = regexp.MustCompile(`^((?:(?:` + csharpModifiers + `)\s+)*)(\w+)\s*\(`)
+	csharpMethodPattern      = regexp.MustCompile(`^((?:(?:` + csharpModifiers + `)\s+)*)([\w.?\[\]]+(?:<.*>)?[?\[\]]*|\(.*\))\s+(\w+)\s*(?:<[^>]*>)?\s*\(`)
+	csharpPropertyPattern    = regexp.MustCompile(`^((?:(?:` + csharpModifiers + `)\s+)*)([\w.?\[\]]+(?:<.*>)?[?\[\]]*)\s+(\w+)\s*(?:\{.*|=>.*)?$`)
+)
+
+// csharpNonTypes are words that are never a member's type
+var csharpNonTypes = map[string]bool{
+	"return": true, "new": true, "throw": true, "else": true, "case": true, "await": true,
+	"event": true, "const": true, "using": true, "var": true, "goto": true,
+}
+
+// parseCSharpFile extracts using directives, namespaces, types, methods,
+// properties and attributes from C# source
+func (s *Summarizer) parseCSharpFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(stripCComments(string(content)), "\n")
+	summary.LineCount = len(lines)
+
+	var scopes scopeStack
+	var namespaces []string
+	attributeCounts := make(map[string]int)
+	var attributeOrder []string
+	depth := 0
+
+	// Members are declared directly inside a type body
+	addMember := func(line string, lineDepth int) {
+		owner, ok := scopes.current()
+		if !ok || !owner.opened || lineDepth != owner.depth+1 {
+			return
+		}
+		fallback := "- "
+		if owner.kind == "interface" {
+			fallback = "+ "
+		}
+
+		simpleName := owner.name[strings.LastIndex(owner.name, ".")+1:]
+		if matches := csharpConstructorPattern.FindStringSubmatch(line); matches != nil && matches[2] == simpleName {
+			summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], fallback)+matches[2])
+			summary.FunctionCount++
+		} else if matches := csharpMethodPattern.FindStringSubmatch(line); matches != nil && !csharpNonTypes[matches[2]] {
+			summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], fallback)+matches[3])
+			summary.FunctionCount++
+		} else if matches := csharpPropertyPattern.FindStringSubmatch(line); matches != nil && !csharpNonTypes[matches[2]] {
+			summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], fallback)+matches[3]+" (property)")
+		}
+	}
+
+	for _, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		lineDepth := depth
+		depth += braceDelta(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if matches := csharpUsingPattern.FindStringSubmatch(line); matches != nil && len(scopes) == 0 {
+			using := matches[1] + matches[2] + matches[4]
+			if matches[3] != "" {
+				using = fmt.Sprintf("%s%s = %s", matches[1], matches[3], matches[4])
+			}
+			summary.Imports = append(summary.Imports, using)
+			continue
+		}
+
+		if matches := csharpNamespacePattern.FindStringSubmatch(line); matches != nil {
+			namespace := matches[1]
+			if matches[2] != "" {
+				namespace += " (file-scoped)"
+			}
+			namespaces = append(namespaces, namespace)
+			continue
+		}
+
+		// Peel off leading attribute lists such as [Serializable, Obsolete("x")]
+		for {
+			matches := csharpAttributePattern.FindStringSubmatch(line)
+			if matches == nil {
+				break
+			}
+			inner := csharpArgumentsPattern.ReplaceAllString(matches[1], "")
+			for _, nameMatch := range csharpAttributeName.FindAllStringSubmatch(inner, -1) {
+				name := "[" + nameMatch[1] + "]"
+				if attributeCounts[name] == 0 {
+					attributeOrder = append(attributeOrder, name)
+				}
+				attributeCounts[name]++
+			}
+			line = line[len(matches[0]):]
+		}
+
+		if matches := csharpTypePattern.FindStringSubmatch(line); matches != nil {
+			kind := strings.Join(strings.Fields(matches[2]), " ")
+			fallback := "~ "
+			if len(scopes) > 0 {
+				fallback = "- "
+			}
+			name := scopes.qualifiedName(matches[3])
+			entry := accessMarker(matches[1], fallback) + name
+
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", entry, kind))
+			switch {
+			case kind == "interface":
+				summary.Interfaces = append(summary.Interfaces, entry)
+			case kind != "enum":
+				summary.Structs = append(summary.Structs, entry)
+			}
+
+			// Positional records such as "record Point(int X, int Y);" have no body
+			if !strings.HasSuffix(line, ";") {
+				scopes = scopes.push(name, kind, lineDepth, strings.Contains(line, "{"))
+				// Members of a body written on the declaration line
+				for _, member := range bodyMembers(line) {
+					addMember(member, lineDepth+1)
+				}
+			}
+			scopes = scopes.update(depth)
+			continue
+		}
+
+		if matches := csharpDelegatePattern.FindStringSubmatch(line); matches != nil {
+			summary.Types = append(summary.Types, fmt.Sprintf("%s%s (delegate)", accessMarker(matches[1], "~ "), scopes.qualifiedName(matches[2])))
+			continue
+		}
+
+		addMember(line, lineDepth)
+		scopes = scopes.update(depth)
+	}
+
+	summary.Package = strings.Join(namespaces, ", ")
+	summary.Annotations = formatCounts(attributeOrder, attributeCounts)
+	return summary
+}
@@ -0,0 +1,320 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// lessMixinPattern matches Less mixin definitions such as ".rounded(@radius: 4px)"
+var lessMixinPattern = regexp.MustCompile(`^[.#][\w-]+\s*\(|\swhen\s`)
+
+// cssImportPathPattern matches the path of an @import, @use or @forward, either
+// quoted or wrapped in url()
+var cssImportPathPattern = regexp.MustCompile(`url\(\s*["']?([^"')]*)["']?\s*\)|"([^"]*)"|'([^']*)'`)
+
+// CSSSummary describes the rules and at-rules of a CSS, SCSS or Less stylesheet
+type CSSSummary struct {
+	Dialect          string   // "CSS", "SCSS" or "Less"
+	Rules            int      // Style rules, including nested ones
+	Selectors        []string // Selectors with counts when repeated
+	Classes          int      // Distinct class names used in selectors
+	IDs              int      // Distinct ids used in selectors
+	CustomProperties []string // --custom-property declarations with values
+	Media            []string // @media queries with counts
+	Keyframes        []string // @keyframes animation names
+	Mixins           []string // SCSS @mixin and @function, Less parametric mixins
+	Includes         []string // Mixins included, with counts
+}
+
+// cssBlock is an open {} block while scanning a stylesheet
+type cssBlock struct {
+	selectors []string // Resolved selectors of the enclosing rule, for nesting
+	keyframes bool     // Inside @keyframes, where selectors are percentages
+}
+
+// parseCSS scans a CSS, SCSS or Less stylesheet for selectors, custom
+// properties, media queries, imports and preprocessor mixins and variables
+func (s *Summarizer) parseCSS(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	css := &CSSSummary{Dialect: "CSS"}
+	switch strings.ToLower(filepath.Ext(fullPath)) {
+	case ".scss":
+		css.Dialect = "SCSS"
+	case ".less":
+		css.Dialect = "Less"
+	}
+
+	selectorCounts := make(map[string]int)
+	var selectorOrder []string
+	mediaCounts := make(map[string]int)
+	var mediaOrder []string
+	includeCounts := make(map[string]int)
+	var includeOrder []string
+	classes := make(map[string]bool)
+	ids := make(map[string]bool)
+	customProperties := make(map[string]bool)
+
+	var stack []cssBlock
+	parent := func() cssBlock {
+		if len(stack) == 0 {
+			return cssBlock{}
+		}
+		return stack[len(stack)-1]
+	}
+
+	// statement handles text ending in ";" or "}", such as declarations,
+	// @import and variable assignments
+	statement := func(text string) {
+		text = normalizeSpace(text)
+		if text == "" {
+			return
+		}
+		name, value, hasValue := strings.Cut(text, ":")
+		keyword := strings.ToLower(strings.Fields(text)[0])
+		switch {
+		case keyword == "@import" || keyword == "@use" || keyword == "@forward":
+			summary.Imports = append(summary.Imports, cssImportPaths(text[len(keyword):])...)
+		case keyword == "@include":
+			include := cssMixinName(text[len(keyword):])
+			if includeCounts[include] == 0 {
+				includeOrder = append(includeOrder, include)
+			}
+			includeCounts[include]++
+		case strings.HasPrefix(text, "--") && hasValue:
+			if name = strings.TrimSpace(name); !customProperties[name] {
+				customProperties[name] = true
+				css.CustomProperties = append(css.CustomProperties, name+": "+strings.TrimSpace(value))
+			}
+		case len(stack) == 0 && hasValue && (css.Dialect == "SCSS" && strings.HasPrefix(text, "$") || css.Dialect == "Less" && strings.HasPrefix(text, "@")):
+			summary.Variables = append(summary.Variables, strings.TrimSpace(name)+": "+strings.TrimSpace(value))
+		case css.Dialect == "Less" && !hasValue && (strings.HasPrefix(text, ".") || strings.HasPrefix(text, "#")):
+			// Less mixin calls look like selectors ending in ";"
+			include := cssMixinName(text)
+			if includeCounts[include] == 0 {
+				includeOrder = append(includeOrder, include)
+			}
+			includeCounts[include]++
+		}
+	}
+
+	// block handles the prelude of a "{" and returns the block it opens
+	block := func(prelude string) cssBlock {
+		prelude = normalizeSpace(prelude)
+		current := parent()
+		opened := cssBlock{selectors: current.selectors}
+		if prelude == "" {
+			return opened
+		}
+
+		if strings.HasPrefix(prelude, "@") && !strings.HasPrefix(prelude, "@{") {
+			keyword, rest, _ := strings.Cut(prelude, " ")
+			switch strings.ToLower(keyword) {
+			case "@media":
+				if mediaCounts[rest] == 0 {
+					mediaOrder = append(mediaOrder, rest)
+				}
+				mediaCounts[rest]++
+			case "@keyframes", "@-webkit-keyframes":
+				css.Keyframes = append(css.Keyframes, rest)
+				opened.keyframes = true
+			case "@mixin":
+				css.Mixins = append(css.Mixins, "@mixin "+rest)
+			case "@function":
+				css.Mixins = append(css.Mixins, "@function "+rest)
+			case "@include":
+				statement(prelude)
+			default:
+				if css.Dialect == "Less" && strings.HasSuffix(keyword, ":") {
+					// Detached ruleset assigned to a variable
+					summary.Variables = append(summary.Variables, strings.TrimSuffix(keyword, ":"))
+				}
+			}
+			return opened
+		}
+		if current.keyframes || strings.HasSuffix(prelude, ":") {
+			// Keyframe steps and SCSS nested properties such as "font: {"
+			return opened
+		}
+
+		// Less mixin definitions are class selectors with parameters or guards
+		if css.Dialect == "Less" && lessMixinPattern.MatchString(prelude) {
+			css.Mixins = append(css.Mixins, prelude)
+			return opened
+		}
+
+		css.Rules++
+		opened.selectors = nil
+		for _, selector := range splitTopLevel(prelude) {
+			selector = strings.TrimSpace(selector)
+			if selector == "" {
+				continue
+			}
+			for _, resolved := range cssNest(current.selectors, selector) {
+				opened.selectors = append(opened.selectors, resolved)
+				if selectorCounts[resolved] == 0 {
+					selectorOrder = append(selectorOrder, resolved)
+				}
+				selectorCounts[resolved]++
+			}
+			for _, class := range cssNames(selector, '.') {
+				classes[class] = true
+			}
+			for _, id := range cssNames(selector, '#') {
+				ids[id] = true
+			}
+		}
+		return opened
+	}
+
+	text := string(content)
+	lineComments := css.Dialect != "CSS"
+	var buffer strings.Builder
+	parens := 0 // Semicolons inside parentheses separate Less mixin parameters
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				i = len(text)
+			} else {
+				i += end + 3
+			}
+			continue
+		case lineComments && strings.HasPrefix(text[i:], "//") && (i == 0 || text[i-1] != ':'):
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				i = len(text)
+			} else {
+				i += end
+			}
+			continue
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(text) && text[end] != c && text[end] != '\n' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end, len(text)-1)
+			buffer.WriteString(text[i : end+1])
+			i = end
+			continue
+		case c == '{' && i > 0 && (text[i-1] == '#' || text[i-1] == '@'):
+			// SCSS #{...} and Less @{...} interpolation
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				end = len(text) - i - 1
+			}
+			buffer.WriteString(text[i : i+end+1])
+			i += end
+			continue
+		case c == '{':
+			parens = 0
+			stack = append(stack, block(buffer.String()))
+			buffer.Reset()
+			continue
+		case c == '(':
+			parens++
+		case c == ')':
+			parens = max(parens-1, 0)
+		case c == ';' && parens == 0:
+			statement(buffer.String())
+			buffer.Reset()
+			continue
+		case c == '}':
+			statement(buffer.String())
+			buffer.Reset()
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		buffer.WriteByte(c)
+	}
+	statement(buffer.String())
+
+	css.Selectors = formatCounts(selectorOrder, selectorCounts)
+	css.Media = formatCounts(mediaOrder, mediaCounts)
+	css.Includes = formatCounts(includeOrder, includeCounts)
+	css.Classes = len(classes)
+	css.IDs = len(ids)
+	summary.CSS = css
+	return summary
+}
+
+// cssNest resolves a nested selector against its parents, replacing "&"
+// or joining with a space
+func cssNest(parents []string, selector string) []string {
+	if len(parents) == 0 {
+		return []string{strings.ReplaceAll(selector, "&", "")}
+	}
+	var resolved []string
+	for _, parent := range parents {
+		if strings.Contains(selector, "&") {
+			resolved = append(resolved, strings.ReplaceAll(selector, "&", parent))
+		} else {
+			resolved = append(resolved, parent+" "+selector)
+		}
+	}
+	return resolved
+}
+
+// cssNames returns the class or id names in a selector, following each
+// occurrence of marker
+func cssNames(selector string, marker byte) []string {
+	var names []string
+	for i := 0; i < len(selector); i++ {
+		if selector[i] != marker || i+1 < len(selector) && selector[i+1] == '{' {
+			continue
+		}
+		end := i + 1
+		for end < len(selector) && (selector[end] == '-' || selector[end] == '_' || selector[end] >= '0' && selector[end] <= '9' ||
+			selector[end] >= 'a' && selector[end] <= 'z' || selector[end] >= 'A' && selector[end] <= 'Z' || selector[end] >= 0x80) {
+			end++
+		}
+		if end > i+1 && (marker != '.' || selector[i+1] < '0' || selector[i+1] > '9') {
+			names = append(names, selector[i+1:end])
+		}
+		i = end - 1
+	}
+	return names
+}
+
+// cssImportPaths returns the bare paths of an import list such as
+// "'base', 'vars'" or "url(print.css) print", dropping media queries,
+// Less options and Sass "as"/"with" clauses
+func cssImportPaths(text string) []string {
+	var paths []string
+	for _, part := range splitTopLevel(text) {
+		if matches := cssImportPathPattern.FindStringSubmatch(part); matches != nil {
+			paths = append(paths, firstNonEmpty(matches[1], matches[2], matches[3]))
+		}
+	}
+	// Plain Sass and Less imports may be unquoted, e.g. "@import foo;"
+	if len(paths) == 0 {
+		if fields := strings.Fields(strings.TrimSuffix(text, ";")); len(fields) > 0 {
+			paths = append(paths, fields[0])
+		}
+	}
+	return paths
+}
+
+// cssMixinName returns the name of an included mixin without its arguments
+func cssMixinName(text string) string {
+	text = strings.TrimSpace(text)
+	if end := strings.IndexAny(text, "( ;"); end > 0 {
+		text = text[:end]
+	}
+	return text
+}
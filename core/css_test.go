@@ -0,0 +1,23 @@
+package core
+
+import "testing"
+
+func TestCSSImportPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"quoted list", "'base', 'vars';", []string{"base", "vars"}},
+		{"url with media query", "url(print.css) print;", []string{"print.css"}},
+		{"quoted url", `url("theme.css") screen and (min-width: 600px);`, []string{"theme.css"}},
+		{"sass with clause", "'config' with ($primary: blue);", []string{"config"}},
+		{"unquoted", "foo;", []string{"foo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertStrings(t, "paths", cssImportPaths(tt.text), tt.want)
+		})
+	}
+}
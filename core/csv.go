@@ -0,0 +1,289 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableSummary describes the schema and leading rows of a CSV/TSV file
+type TableSummary struct {
+	Delimiter string          // "," ";" "|" or "\t"
+	HasHeader bool            // Whether the first row names the columns
+	Rows      int             // Data rows, excluding the header
+	Columns   []ColumnSummary // Per-column schema
+	Preview   [][]string      // Header (if any) followed by the first rows
+}
+
+// ColumnSummary describes one column of a table
+type ColumnSummary struct {
+	Name     string
+	Type     string // int, float, bool, date or string
+	Nulls    int    // Empty or NULL-like cells
+	Distinct int    // Distinct non-null values, capped at maxDistinctValues
+}
+
+const (
+	csvPreviewRows    = 10    // Rows kept for the table preview
+	csvSniffLines     = 20    // Lines inspected to detect the delimiter
+	maxDistinctValues = 10000 // Distinct values tracked per column
+)
+
+// csvDelimiters are the candidate delimiters, in order of preference
+var csvDelimiters = []rune{',', '\t', ';', '|'}
+
+// csvNulls are cell values treated as missing
+var csvNulls = map[string]bool{"": true, "null": true, "NULL": true, "NA": true, "N/A": true, "nan": true, "NaN": true, "-": true}
+
+// csvDateLayouts are the date formats recognized when inferring column types
+var csvDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/01/02",
+	"01/02/2006",
+	"02.01.2006",
+}
+
+// columnStats accumulates type and value information for one column
+type columnStats struct {
+	nulls   int
+	isInt   bool
+	isFloat bool
+	isBool  bool
+	isDate  bool
+	values  map[string]bool
+}
+
+// parseCSV streams a CSV or TSV file, detecting its delimiter and header and
+// inferring a type, null count and distinct count for every column
+func (s *Summarizer) parseCSV(fullPath string, summary FileSummary) FileSummary {
+	file, err := os.Open(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error opening file: %v", err)
+		return summary
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReaderSize(file, 64*1024)
+	head, _ := buffered.Peek(64 * 1024)
+	delimiter := detectDelimiter(head, strings.EqualFold(filepath.Ext(fullPath), ".tsv"))
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	table := &TableSummary{Delimiter: string(delimiter)}
+	var stats []*columnStats
+	var header []string
+	firstRow := true
+	lastLine := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			summary.Error = fmt.Sprintf("Invalid CSV: %v", err)
+			return summary
+		}
+		lastLine, _ = reader.FieldPos(len(record) - 1)
+
+		// Only the first row can be a header
+		isHeader := firstRow && looksLikeHeader(record)
+		firstRow = false
+		if isHeader {
+			table.HasHeader = true
+			header = record
+			table.Preview = append(table.Preview, record)
+			// Seed the columns from the header, so they are listed even
+			// when no data row is long enough to reach them
+			for range header {
+				stats = append(stats, newColumnStats(0))
+			}
+			continue
+		}
+		stats = addTableRow(table, stats, record)
+	}
+
+	for i, column := range stats {
+		name := fmt.Sprintf("column %d", i+1)
+		if i < len(header) && strings.TrimSpace(header[i]) != "" {
+			name = strings.TrimSpace(header[i])
+		}
+		table.Columns = append(table.Columns, ColumnSummary{
+			Name:     name,
+			Type:     column.inferredType(),
+			Nulls:    column.nulls,
+			Distinct: len(column.values),
+		})
+	}
+
+	summary.LineCount = lastLine
+	summary.Table = table
+	return summary
+}
+
+// addTableRow records a data row in the column statistics and the preview
+func addTableRow(table *TableSummary, stats []*columnStats, record []string) []*columnStats {
+	table.Rows++
+	limit := csvPreviewRows
+	if table.HasHeader {
+		limit++
+	}
+	if len(table.Preview) < limit {
+		table.Preview = append(table.Preview, record)
+	}
+
+	for len(stats) < len(record) {
+		// Columns that appear late were null in every earlier row
+		stats = append(stats, newColumnStats(table.Rows-1))
+	}
+	for i, column := range stats {
+		value := ""
+		if i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+		column.add(value)
+	}
+	return stats
+}
+
+// newColumnStats starts a column that may still hold any type
+func newColumnStats(nulls int) *columnStats {
+	return &columnStats{
+		nulls:   nulls,
+		isInt:   true,
+		isFloat: true,
+		isBool:  true,
+		isDate:  true,
+		values:  make(map[string]bool),
+	}
+}
+
+// add updates the column with one cell value
+func (c *columnStats) add(value string) {
+	if csvNulls[value] {
+		c.nulls++
+		return
+	}
+	if len(c.values) < maxDistinctValues {
+		c.values[value] = true
+	}
+	if c.isInt {
+		_, err := strconv.ParseInt(value, 10, 64)
+		c.isInt = err == nil
+	}
+	if c.isFloat {
+		_, err := strconv.ParseFloat(value, 64)
+		c.isFloat = err == nil
+	}
+	if c.isBool {
+		c.isBool = isBoolValue(value)
+	}
+	if c.isDate {
+		c.isDate = isDateValue(value)
+	}
+}
+
+// inferredType returns the narrowest type that fits every non-null value
+func (c *columnStats) inferredType() string {
+	switch {
+	case len(c.values) == 0:
+		return "string"
+	case c.isBool:
+		return "bool"
+	case c.isInt:
+		return "int"
+	case c.isFloat:
+		return "float"
+	case c.isDate:
+		return "date"
+	}
+	return "string"
+}
+
+// detectDelimiter picks the candidate that splits the first lines into the
+// same number of fields most often, preferring tabs for .tsv files
+func detectDelimiter(head []byte, tsv bool) rune {
+	if tsv {
+		return '\t'
+	}
+
+	lines := bytes.Split(head, []byte("\n"))
+	if len(lines) > csvSniffLines {
+		lines = lines[:csvSniffLines]
+	}
+	// The last line of the sample may be cut off
+	if len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+
+	best, bestScore := ',', 0
+	for _, delimiter := range csvDelimiters {
+		counts := make(map[int]int)
+		for _, line := range lines {
+			if count := bytes.Count(line, []byte(string(delimiter))); count > 0 {
+				counts[count]++
+			}
+		}
+		// Score by how many lines share the most common field count
+		score := 0
+		for _, lineCount := range counts {
+			if lineCount > score {
+				score = lineCount
+			}
+		}
+		if score > bestScore {
+			best, bestScore = delimiter, score
+		}
+	}
+	return best
+}
+
+// looksLikeHeader reports whether a row names columns: every cell is a
+// distinct, non-empty label rather than a number, boolean or date
+func looksLikeHeader(row []string) bool {
+	seen := make(map[string]bool)
+	for _, cell := range row {
+		cell = strings.TrimSpace(cell)
+		if cell == "" || seen[cell] || isBoolValue(cell) || isDateValue(cell) {
+			return false
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			return false
+		}
+		seen[cell] = true
+	}
+	return len(row) > 0
+}
+
+// isBoolValue reports whether a cell holds a boolean literal
+func isBoolValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no":
+		return true
+	}
+	return false
+}
+
+// isDateValue reports whether a cell matches one of the known date layouts
+func isDateValue(value string) bool {
+	for _, layout := range csvDateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
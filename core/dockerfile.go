@@ -0,0 +1,306 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DockerSummary describes the build stages and runtime settings of a Dockerfile
+type DockerSummary struct {
+	Stages     []string // One entry per FROM, e.g. "builder ← golang:1.22"
+	BaseImages []string // External images the build starts from
+	Ports      []string // Ports declared with EXPOSE
+	Env        []string // Keys set with ENV
+	Args       []string // Build arguments, with defaults when given
+	Entrypoint string   // Effective ENTRYPOINT of the final stage
+	Cmd        string   // Effective CMD of the final stage
+	Sources    []string // COPY/ADD sources, prefixed with the stage they come from
+}
+
+// dockerStage tracks the settings a stage passes on to stages built from it
+type dockerStage struct {
+	entrypoint string
+	cmd        string
+}
+
+var (
+	// dockerEscapePattern matches the parser directive that changes the escape character
+	dockerEscapePattern = regexp.MustCompile(`(?i)^#\s*escape\s*=\s*([\\` + "`" + `])\s*$`)
+	// dockerHeredocPattern matches heredoc markers such as <<EOF or <<-"EOF"
+	dockerHeredocPattern = regexp.MustCompile(`<<-?(["']?)([A-Za-z_][A-Za-z0-9_]*)(["']?)`)
+	// dockerInstructionPattern matches the leading instruction keyword of a line
+	dockerInstructionPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\s+|$)`)
+)
+
+// isDockerfile reports whether content starts like a Dockerfile: optional
+// comments and ARG lines followed by a FROM instruction. Only upper-case
+// keywords count, so Python's "from x import y" is not mistaken for one.
+func isDockerfile(head []byte) bool {
+	for _, line := range strings.Split(string(head), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "FROM":
+			return len(fields) > 1
+		case "ARG":
+			continue
+		}
+		return false
+	}
+	return false
+}
+
+// parseDockerfile lists stages, base images, exposed ports, ENV/ARG keys,
+// the effective ENTRYPOINT/CMD and COPY/ADD sources of a Dockerfile
+func (s *Summarizer) parseDockerfile(fullPath string, summary FileSummary) FileSummary {
+	file, err := os.Open(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error opening file: %v", err)
+		return summary
+	}
+	defer file.Close()
+
+	docker := &DockerSummary{}
+	stages := make(map[string]dockerStage)
+	var current dockerStage
+	var currentKeys []string              // Name and index of the current stage
+	cmdInStage := false                   // Whether the current stage sets CMD itself
+	globalArgs := make(map[string]string) // ARG defaults declared before the first FROM
+	escape := `\`
+	directives := true // Parser directives are only valid before anything else
+
+	var instruction strings.Builder
+	var heredoc string
+	lineCount := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineCount++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		// Skip heredoc bodies, e.g. RUN <<EOF ... EOF
+		if heredoc != "" {
+			if strings.TrimSpace(line) == heredoc {
+				heredoc = ""
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			if directives {
+				if matches := dockerEscapePattern.FindStringSubmatch(trimmed); matches != nil {
+					escape = matches[1]
+				}
+			}
+			// Comments may appear between continuation lines
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		directives = false
+
+		// Join continuation lines into a single instruction
+		if strings.HasSuffix(trimmed, escape) {
+			instruction.WriteString(strings.TrimSuffix(trimmed, escape) + " ")
+			continue
+		}
+		instruction.WriteString(trimmed)
+		text := instruction.String()
+		instruction.Reset()
+
+		if matches := dockerHeredocPattern.FindStringSubmatch(text); matches != nil {
+			heredoc = matches[2]
+		}
+
+		matches := dockerInstructionPattern.FindStringSubmatch(text)
+		if matches == nil {
+			continue
+		}
+		keyword := strings.ToUpper(matches[1])
+		args := strings.TrimSpace(text[len(matches[0]):])
+
+		switch keyword {
+		case "FROM":
+			for _, key := range currentKeys {
+				stages[key] = current
+			}
+			cmdInStage = false
+			image, name := parseDockerFrom(args)
+			image = expandDockerArgs(image, globalArgs)
+			if parent, isStage := stages[strings.ToLower(image)]; isStage {
+				current = parent
+			} else {
+				current = dockerStage{}
+				docker.BaseImages = append(docker.BaseImages, image)
+			}
+
+			// Stages can be referenced by index as well as by name
+			index := len(docker.Stages)
+			currentKeys = []string{fmt.Sprint(index)}
+			label := fmt.Sprintf("#%d", index)
+			if name != "" {
+				currentKeys = append(currentKeys, strings.ToLower(name))
+				label = name
+			}
+			docker.Stages = append(docker.Stages, fmt.Sprintf("%s ← %s", label, image))
+
+		case "EXPOSE":
+			docker.Ports = append(docker.Ports, strings.Fields(args)...)
+
+		case "ENV":
+			docker.Env = append(docker.Env, dockerEnvKeys(args)...)
+
+		case "ARG":
+			for _, arg := range strings.Fields(args) {
+				arg = strings.Trim(arg, `"`)
+				docker.Args = append(docker.Args, arg)
+				if key, value, found := strings.Cut(arg, "="); found && len(docker.Stages) == 0 {
+					globalArgs[key] = value
+				}
+			}
+
+		case "ENTRYPOINT":
+			current.entrypoint = dockerCommand(args)
+			// Setting ENTRYPOINT clears any CMD inherited from the base stage
+			if !cmdInStage {
+				current.cmd = ""
+			}
+
+		case "CMD":
+			current.cmd = dockerCommand(args)
+			cmdInStage = true
+
+		case "COPY", "ADD":
+			docker.Sources = append(docker.Sources, dockerCopySources(args)...)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	docker.BaseImages = uniqueStrings(docker.BaseImages)
+	docker.Env = uniqueStrings(docker.Env)
+	docker.Args = uniqueStrings(docker.Args)
+	docker.Ports = uniqueStrings(docker.Ports)
+	docker.Sources = uniqueStrings(docker.Sources)
+	docker.Entrypoint = current.entrypoint
+	docker.Cmd = current.cmd
+
+	summary.LineCount = lineCount
+	summary.Docker = docker
+	return summary
+}
+
+// parseDockerFrom splits FROM arguments into the image and optional stage name
+func parseDockerFrom(args string) (image, name string) {
+	var fields []string
+	for _, field := range strings.Fields(args) {
+		// Skip flags such as --platform=linux/amd64
+		if !strings.HasPrefix(field, "--") {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return "", ""
+	}
+	image = fields[0]
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+		name = fields[2]
+	}
+	return image, name
+}
+
+// expandDockerArgs substitutes $NAME and ${NAME} references to global build
+// arguments with their defaults, leaving unknown references untouched
+func expandDockerArgs(value string, args map[string]string) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+	return os.Expand(value, func(name string) string {
+		if arg, exists := args[name]; exists {
+			return arg
+		}
+		return "${" + name + "}"
+	})
+}
+
+// dockerEnvKeys returns the keys set by ENV in either the KEY=value form or
+// the legacy KEY value form
+func dockerEnvKeys(args string) []string {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return nil
+	}
+	if !strings.Contains(fields[0], "=") {
+		return []string{fields[0]}
+	}
+
+	var keys []string
+	for _, field := range fields {
+		// Words without "=" belong to a quoted value with spaces
+		if key, _, found := strings.Cut(field, "="); found && key != "" && !strings.ContainsAny(key, `"'`) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// dockerCommand renders ENTRYPOINT/CMD arguments, joining the exec (JSON) form
+// into a single command line
+func dockerCommand(args string) string {
+	var exec []string
+	if err := json.Unmarshal([]byte(args), &exec); err == nil {
+		return strings.Join(exec, " ")
+	}
+	return args
+}
+
+// dockerCopySources returns the source paths of a COPY or ADD instruction,
+// prefixing sources copied from another stage or image with its name
+func dockerCopySources(args string) []string {
+	var from string
+	var paths []string
+
+	var exec []string
+	if strings.HasPrefix(args, "[") && json.Unmarshal([]byte(args), &exec) == nil {
+		paths = exec
+	} else {
+		for _, field := range strings.Fields(args) {
+			if strings.HasPrefix(field, "--") {
+				if value, found := strings.CutPrefix(field, "--from="); found {
+					from = value
+				}
+				continue
+			}
+			paths = append(paths, field)
+		}
+	}
+
+	// The last path is the destination; heredoc sources have no path
+	if len(paths) < 2 {
+		return nil
+	}
+	var sources []string
+	for _, path := range paths[:len(paths)-1] {
+		if strings.HasPrefix(path, "<<") {
+			continue
+		}
+		if from != "" {
+			path = from + ":" + path
+		}
+		sources = append(sources, path)
+	}
+	return sources
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	followTailLines   = 20      // Lines shown when following starts
+	maxFollowReadSize = 4 << 20 // Bytes read per poll, so a burst cannot stall the UI
+	maxFollowLineSize = 1 << 20 // Longest unterminated line held back before it is shown as is
+)
+
+// Follower tails a growing file like tail -f, detecting truncation and
+// rotation (the path being replaced by a new file)
+type Follower struct {
+	mu        sync.Mutex // Poll runs on a timer while Close comes from the UI
+	closed    bool
+	path      string
+	file      *os.File
+	offset    int64  // Position after the last complete line read
+	partial   []byte // Bytes of a line that has no newline yet
+	continued bool   // Part of the current line was already shown and counted
+	lineCount int
+}
+
+// FollowUpdate describes what changed in a followed file since the last poll
+type FollowUpdate struct {
+	Lines     []string // Complete lines appended since the last poll, plus any overlong partial line
+	LineCount int      // Total lines in the file
+	Truncated bool     // The file shrank and is being read from the start
+	Rotated   bool     // The path now refers to a different file
+}
+
+// NewFollower opens a file for following and returns its last lines
+func NewFollower(fullPath string) (*Follower, FollowUpdate, error) {
+	f := &Follower{path: fullPath}
+	if err := f.open(); err != nil {
+		return nil, FollowUpdate{}, err
+	}
+
+	// Count lines and keep the tail in a ring buffer
+	tail := make([]string, 0, followTailLines)
+	reader := bufio.NewReader(f.file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err != io.EOF {
+				f.file.Close()
+				return nil, FollowUpdate{}, fmt.Errorf("reading %s: %w", fullPath, err)
+			}
+			f.partial = line
+			break
+		}
+		f.offset += int64(len(line))
+		f.lineCount++
+		if len(tail) == followTailLines {
+			tail = tail[1:]
+		}
+		tail = append(tail, string(bytes.TrimRight(line, "\r\n")))
+	}
+	f.offset += int64(len(f.partial))
+
+	return f, FollowUpdate{Lines: tail, LineCount: f.lineCount}, nil
+}
+
+// Poll reads lines appended since the previous call. A missing file is not an
+// error, since rotation may briefly leave no file at the path.
+func (f *Follower) Poll() (FollowUpdate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var update FollowUpdate
+	if f.closed {
+		return update, os.ErrClosed
+	}
+
+	pathInfo, err := os.Stat(f.path)
+	if err != nil {
+		update.LineCount = f.lineCount
+		return update, nil
+	}
+	openInfo, err := f.file.Stat()
+	if err != nil {
+		return update, err
+	}
+
+	switch {
+	case !os.SameFile(pathInfo, openInfo):
+		f.file.Close()
+		if err := f.open(); err != nil {
+			return update, err
+		}
+		f.reset()
+		update.Rotated = true
+	case pathInfo.Size() < f.offset:
+		f.reset()
+		update.Truncated = true
+	}
+
+	size := pathInfo.Size() - f.offset
+	if size > maxFollowReadSize {
+		size = maxFollowReadSize
+	}
+	if size > 0 {
+		data := make([]byte, size)
+		n, err := f.file.ReadAt(data, f.offset)
+		if err != nil && err != io.EOF {
+			return update, err
+		}
+		f.offset += int64(n)
+
+		data = append(f.partial, data[:n]...)
+		end := bytes.LastIndexByte(data, '\n')
+		if end >= 0 {
+			for _, line := range bytes.Split(data[:end], []byte("\n")) {
+				update.Lines = append(update.Lines, string(bytes.TrimRight(line, "\r")))
+			}
+			f.lineCount += len(update.Lines)
+			if f.continued {
+				// The first line ends one that was already counted
+				f.lineCount--
+				f.continued = false
+			}
+		}
+		f.partial = append([]byte(nil), data[end+1:]...)
+
+		// Output that never ends a line, such as a progress bar, is shown
+		// in pieces rather than buffered forever
+		if len(f.partial) > maxFollowLineSize {
+			update.Lines = append(update.Lines, string(f.partial))
+			f.partial = nil
+			if !f.continued {
+				f.lineCount++
+				f.continued = true
+			}
+		}
+	}
+
+	update.LineCount = f.lineCount
+	return update, nil
+}
+
+// Close releases the followed file
+func (f *Follower) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	return f.file.Close()
+}
+
+// open opens the file currently at the followed path
+func (f *Follower) open() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	f.file = file
+	return nil
+}
+
+// reset starts reading the open file from the beginning
+func (f *Follower) reset() {
+	f.offset = 0
+	f.partial = nil
+	f.continued = false
+	f.lineCount = 0
+}
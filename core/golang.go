@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+)
+
+// parseGoFile parses Go source with go/parser and walks the resulting AST
+func (s *Summarizer) parseGoFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, fullPath, content, parser.SkipObjectResolution)
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+	// A file with syntax errors still yields a partial AST, which is
+	// more useful to show than an error message on its own. Without a
+	// package clause there is nothing to show, so report the error
+	if file == nil || file.Package == token.NoPos {
+		summary.Error = fmt.Sprintf("Error parsing Go file: %v", err)
+		return summary
+	}
+	if err != nil {
+		summary.Warning = fmt.Sprintf("Syntax error, summary may be incomplete: %v", err)
+	}
+
+	summary.Package = file.Name.Name
+
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, "\"`")
+		if imp.Name != nil {
+			path = fmt.Sprintf("%s (%s)", path, imp.Name.Name)
+		}
+		summary.Imports = append(summary.Imports, path)
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			name := goVisibility(d.Name.Name) + d.Name.Name + goTypeParams(d.Type.TypeParams)
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				receiver := goReceiverName(d.Recv.List[0].Type)
+				summary.Members[receiver] = append(summary.Members[receiver], name)
+			} else {
+				summary.Functions = append(summary.Functions, name)
+			}
+			summary.FunctionCount++
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					name := goVisibility(sp.Name.Name) + sp.Name.Name + goTypeParams(sp.TypeParams)
+					summary.Types = append(summary.Types, name)
+					switch sp.Type.(type) {
+					case *ast.StructType:
+						summary.Structs = append(summary.Structs, name)
+					case *ast.InterfaceType:
+						summary.Interfaces = append(summary.Interfaces, name)
+					}
+
+				case *ast.ValueSpec:
+					for _, ident := range sp.Names {
+						if ident.Name == "_" {
+							continue
+						}
+						name := goVisibility(ident.Name) + ident.Name
+						if d.Tok == token.CONST {
+							summary.Constants = append(summary.Constants, name)
+						} else {
+							summary.Variables = append(summary.Variables, name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return summary
+}
+
+// goVisibility returns the exported/unexported marker for a Go identifier
+func goVisibility(name string) string {
+	if ast.IsExported(name) {
+		return "+ "
+	}
+	return "- "
+}
+
+// goTypeParams renders a generic type parameter list such as [K comparable, V any]
+func goTypeParams(params *ast.FieldList) string {
+	if params == nil || len(params.List) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, field := range params.List {
+		var names []string
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+types.ExprString(field.Type))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// goReceiverName strips pointers and type arguments from a method receiver
+func goReceiverName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return types.ExprString(expr)
+		}
+	}
+}
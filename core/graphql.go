@@ -0,0 +1,411 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// GraphQLSummary describes the type system and operations of a GraphQL document
+type GraphQLSummary struct {
+	Types         []string     // Object types with field counts and interfaces
+	Inputs        []string     // Input types with field counts
+	Interfaces    []string     // Interfaces with field counts
+	Enums         []string     // Enums with value counts
+	Unions        []string     // Unions with their members
+	Scalars       []string     // Custom scalars
+	RootFields    []Definition // Query, Mutation and Subscription fields
+	Directives    []string     // Directive definitions with their locations
+	DirectiveUses []string     // Directives applied in the document, with counts
+	Operations    []string     // Named queries, mutations and subscriptions with variables
+	Fragments     []string     // Fragments with their type conditions
+}
+
+// graphqlType is a type definition collected before roots are known, since
+// a schema block may rename them after the types are defined
+type graphqlType struct {
+	name       string
+	kind       string // "type", "input", "interface" or "enum"
+	implements []string
+	fields     []string
+}
+
+// graphqlParser walks the tokens of a GraphQL document
+type graphqlParser struct {
+	tokenStream
+	types     []*graphqlType
+	byName    map[string]*graphqlType
+	useCounts map[string]int
+	useOrder  []string
+}
+
+// parseGraphQL extracts the types, root fields and directives of GraphQL
+// schemas, and the operations and fragments of query documents
+func (s *Summarizer) parseGraphQL(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	graphql := &GraphQLSummary{}
+	p := &graphqlParser{
+		tokenStream: tokenStream{tokens: tokenizeGraphQL(string(content))},
+		byName:      make(map[string]*graphqlType),
+		useCounts:   make(map[string]int),
+	}
+	roots := map[string]string{"query": "Query", "mutation": "Mutation", "subscription": "Subscription"}
+
+	for !p.done() {
+		token := p.next()
+		if strings.HasPrefix(token, `"`) {
+			continue // Description of the next definition
+		}
+		if token == "extend" {
+			token = p.next()
+		}
+
+		switch token {
+		case "schema":
+			p.directives()
+			if p.expect("{") {
+				for !p.done() && !p.expect("}") {
+					operation := p.next()
+					p.expect(":")
+					roots[operation] = p.next()
+				}
+			}
+		case "scalar":
+			graphql.Scalars = append(graphql.Scalars, p.next())
+			p.directives()
+		case "type", "input", "interface", "enum":
+			p.typeDefinition(token)
+		case "union":
+			name := p.next()
+			p.directives()
+			var members []string
+			if p.expect("=") {
+				p.expect("|")
+				members = append(members, p.next())
+				for p.expect("|") {
+					members = append(members, p.next())
+				}
+			}
+			graphql.Unions = append(graphql.Unions, name+" = "+strings.Join(members, " | "))
+		case "directive":
+			p.expect("@")
+			directive := "@" + p.next() + p.arguments()
+			if p.expect("repeatable") {
+				directive += " repeatable"
+			}
+			if p.expect("on") {
+				p.expect("|")
+				locations := []string{p.next()}
+				for p.expect("|") {
+					locations = append(locations, p.next())
+				}
+				directive += " on " + strings.Join(locations, " | ")
+			}
+			graphql.Directives = append(graphql.Directives, directive)
+		case "query", "mutation", "subscription":
+			operation := token
+			if name := p.peek(); name != "(" && name != "{" && name != "@" {
+				operation += " " + p.next()
+			} else {
+				operation += " (anonymous)"
+			}
+			operation += p.variables()
+			p.directives()
+			p.selectionSet()
+			graphql.Operations = append(graphql.Operations, operation)
+		case "fragment":
+			fragment := p.next()
+			if p.expect("on") {
+				fragment += " on " + p.next()
+			}
+			p.directives()
+			p.selectionSet()
+			graphql.Fragments = append(graphql.Fragments, fragment)
+		case "{":
+			// Anonymous query shorthand
+			p.pos--
+			p.selectionSet()
+			graphql.Operations = append(graphql.Operations, "query (anonymous)")
+		}
+	}
+
+	// Root operation types are listed by field rather than as types
+	rootNames := make(map[string]bool)
+	for _, operation := range []string{"query", "mutation", "subscription"} {
+		if root := p.byName[roots[operation]]; root != nil && root.kind == "type" {
+			rootNames[root.name] = true
+			graphql.RootFields = append(graphql.RootFields, Definition{Name: root.name, Entries: root.fields})
+		}
+	}
+	for _, definition := range p.types {
+		entry := fmt.Sprintf("%s (%s)", definition.name, pluralize(len(definition.fields), "field"))
+		if len(definition.implements) > 0 {
+			entry += " implements " + strings.Join(definition.implements, " & ")
+		}
+		switch definition.kind {
+		case "type":
+			if !rootNames[definition.name] {
+				graphql.Types = append(graphql.Types, entry)
+			}
+		case "input":
+			graphql.Inputs = append(graphql.Inputs, entry)
+		case "interface":
+			graphql.Interfaces = append(graphql.Interfaces, entry)
+		case "enum":
+			graphql.Enums = append(graphql.Enums, fmt.Sprintf("%s (%s)", definition.name, pluralize(len(definition.fields), "value")))
+		}
+	}
+	graphql.DirectiveUses = formatCounts(p.useOrder, p.useCounts)
+
+	summary.GraphQL = graphql
+	return summary
+}
+
+// tokenizeGraphQL splits a GraphQL document into names, punctuators and
+// strings, dropping comments and commas
+func tokenizeGraphQL(content string) []string {
+	var tokens []string
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				return tokens
+			}
+			i += end
+		case strings.HasPrefix(content[i:], `"""`):
+			end := strings.Index(content[i+3:], `"""`)
+			if end < 0 {
+				return tokens
+			}
+			tokens = append(tokens, content[i:i+end+6])
+			i += end + 6
+		case c == '"':
+			end := i + 1
+			for end < len(content) && content[end] != '"' && content[end] != '\n' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(content))
+			tokens = append(tokens, content[i:end])
+			i = end
+		case strings.HasPrefix(content[i:], "..."):
+			tokens = append(tokens, "...")
+			i += 3
+		case c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			end := i + 1
+			for end < len(content) {
+				d := content[end]
+				if !(d == '_' || d == '.' || d >= '0' && d <= '9' || d >= 'a' && d <= 'z' || d >= 'A' && d <= 'Z') {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, content[i:end])
+			i = end
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+// skipBalanced consumes tokens up to the close token matching an already
+// consumed open token, returning them joined for display
+func (p *graphqlParser) skipBalanced(open, close string) string {
+	var parts []string
+	for depth := 1; !p.done(); {
+		token := p.next()
+		switch token {
+		case open:
+			depth++
+		case close:
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+		parts = append(parts, token)
+	}
+	return strings.Join(parts, " ")
+}
+
+// directives consumes any applied directives, counting each use
+func (p *graphqlParser) directives() {
+	for p.expect("@") {
+		name := "@" + p.next()
+		if p.useCounts[name] == 0 {
+			p.useOrder = append(p.useOrder, name)
+		}
+		p.useCounts[name]++
+		if p.expect("(") {
+			p.skipBalanced("(", ")")
+		}
+	}
+}
+
+// typeRef reads a type reference such as [String!]!
+func (p *graphqlParser) typeRef() string {
+	var ref string
+	if p.expect("[") {
+		ref = "[" + p.typeRef() + "]"
+		p.expect("]")
+	} else {
+		ref = p.next()
+	}
+	if p.expect("!") {
+		ref += "!"
+	}
+	return ref
+}
+
+// value reads an input value, such as a default, for display; object and
+// list values are abbreviated
+func (p *graphqlParser) value() string {
+	switch token := p.next(); token {
+	case "{":
+		if p.skipBalanced("{", "}") == "" {
+			return "{}"
+		}
+		return "{...}"
+	case "[":
+		if p.skipBalanced("[", "]") == "" {
+			return "[]"
+		}
+		return "[...]"
+	case "$":
+		return "$" + p.next()
+	default:
+		return token
+	}
+}
+
+// arguments reads an argument definition list as "(name: Type, ...)"
+func (p *graphqlParser) arguments() string {
+	if !p.expect("(") {
+		return ""
+	}
+	var arguments []string
+	for !p.done() && !p.expect(")") {
+		name := p.next()
+		if strings.HasPrefix(name, `"`) {
+			continue // Argument description
+		}
+		p.expect(":")
+		argument := name + ": " + p.typeRef()
+		if p.expect("=") {
+			argument += " = " + p.value()
+		}
+		p.directives()
+		arguments = append(arguments, argument)
+	}
+	return "(" + strings.Join(arguments, ", ") + ")"
+}
+
+// variables reads an operation's variable definitions as "($id: ID!, ...)"
+func (p *graphqlParser) variables() string {
+	if !p.expect("(") {
+		return ""
+	}
+	var variables []string
+	for !p.done() && !p.expect(")") {
+		p.expect("$")
+		variable := "$" + p.next()
+		p.expect(":")
+		variable += ": " + p.typeRef()
+		if p.expect("=") {
+			variable += " = " + p.value()
+		}
+		p.directives()
+		variables = append(variables, variable)
+	}
+	return "(" + strings.Join(variables, ", ") + ")"
+}
+
+// selectionSet consumes a selection set, counting directives used inside it
+func (p *graphqlParser) selectionSet() {
+	if !p.expect("{") {
+		return
+	}
+	for depth := 1; depth > 0 && !p.done(); {
+		switch p.peek() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		case "@":
+			p.directives()
+			continue
+		case "(":
+			p.next()
+			p.skipBalanced("(", ")")
+			continue
+		}
+		p.next()
+	}
+}
+
+// typeDefinition reads an object, input, interface or enum definition,
+// merging extensions into the type they extend
+func (p *graphqlParser) typeDefinition(kind string) {
+	name := p.next()
+	definition := p.byName[name]
+	if definition == nil {
+		definition = &graphqlType{name: name, kind: kind}
+		p.byName[name] = definition
+		p.types = append(p.types, definition)
+	}
+
+	if p.expect("implements") {
+		p.expect("&")
+		definition.implements = append(definition.implements, p.next())
+		for p.expect("&") {
+			definition.implements = append(definition.implements, p.next())
+		}
+	}
+	p.directives()
+	if !p.expect("{") {
+		return
+	}
+
+	for !p.done() && !p.expect("}") {
+		field := p.next()
+		if strings.HasPrefix(field, `"`) {
+			continue // Field description
+		}
+		if kind == "enum" {
+			p.directives()
+			definition.fields = append(definition.fields, field)
+			continue
+		}
+		field += p.arguments()
+		if p.expect(":") {
+			field += ": " + p.typeRef()
+		}
+		if kind == "input" && p.expect("=") {
+			field += " = " + p.value()
+		}
+		p.directives()
+		definition.fields = append(definition.fields, field)
+	}
+}
+
+// pluralize formats a count with a noun, adding "s" unless the count is one
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
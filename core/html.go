@@ -0,0 +1,220 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLSummary describes the head and structure of an HTML document
+type HTMLSummary struct {
+	Title       string
+	Lang        string
+	Elements    int      // Total element count
+	Meta        []string // Meta tags and head links, e.g. "description: ..."
+	Scripts     []string // External and inline scripts
+	Stylesheets []string // Linked and inline stylesheets
+	IDs         []string // Element ids, with counts when repeated
+	Forms       []string // Forms with method, action and field names
+}
+
+// parseHTML extracts the title, meta tags, scripts, stylesheets, ids, forms
+// and heading outline of an HTML document
+func (s *Summarizer) parseHTML(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	document, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		summary.Error = fmt.Sprintf("Invalid HTML: %v", err)
+		return summary
+	}
+
+	page := &HTMLSummary{}
+	idCounts := make(map[string]int)
+	var idOrder []string
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			page.Elements++
+			if id := htmlAttr(node, "id"); id != "" {
+				if idCounts[id] == 0 {
+					idOrder = append(idOrder, id)
+				}
+				idCounts[id]++
+			}
+
+			switch node.DataAtom {
+			case atom.Html:
+				page.Lang = htmlAttr(node, "lang")
+			case atom.Title:
+				if page.Title == "" {
+					page.Title = htmlText(node)
+				}
+			case atom.Meta:
+				if meta := htmlMeta(node); meta != "" {
+					page.Meta = append(page.Meta, meta)
+				}
+			case atom.Link:
+				rel := strings.ToLower(htmlAttr(node, "rel"))
+				href := htmlAttr(node, "href")
+				switch {
+				case strings.Contains(rel, "stylesheet"):
+					stylesheet := href
+					if media := htmlAttr(node, "media"); media != "" {
+						stylesheet += " (media: " + media + ")"
+					}
+					page.Stylesheets = append(page.Stylesheets, stylesheet)
+				case rel != "" && href != "":
+					page.Meta = append(page.Meta, fmt.Sprintf("link %s: %s", rel, href))
+				}
+			case atom.Style:
+				page.Stylesheets = append(page.Stylesheets, "inline <style> ("+htmlLines(node)+")")
+			case atom.Script:
+				page.Scripts = append(page.Scripts, htmlScript(node))
+			case atom.Form:
+				page.Forms = append(page.Forms, htmlForm(node))
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				level := int(node.Data[1] - '0')
+				if text := htmlText(node); text != "" {
+					summary.Headers = append(summary.Headers, strings.Repeat("#", level)+" "+text)
+				}
+			case atom.A:
+				if href := htmlAttr(node, "href"); href != "" && !strings.HasPrefix(href, "#") {
+					summary.Links = append(summary.Links, fmt.Sprintf("[%s](%s)", htmlText(node), href))
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(document)
+
+	page.IDs = formatCounts(idOrder, idCounts)
+	summary.HTML = page
+	return summary
+}
+
+// htmlAttr returns the value of an attribute, or ""
+func htmlAttr(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
+
+// htmlHasAttr reports whether an attribute is present, such as "defer"
+func htmlHasAttr(node *html.Node, name string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlText returns the whitespace-collapsed text inside a node
+func htmlText(node *html.Node) string {
+	var text strings.Builder
+	var collect func(node *html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			text.WriteString(node.Data)
+			text.WriteString(" ")
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(node)
+	return normalizeSpace(text.String())
+}
+
+// htmlLines describes the size of the text inside a script or style element
+func htmlLines(node *html.Node) string {
+	lines := 0
+	if node.FirstChild != nil {
+		if text := strings.TrimSpace(node.FirstChild.Data); text != "" {
+			lines = strings.Count(text, "\n") + 1
+		}
+	}
+	return pluralize(lines, "line")
+}
+
+// htmlMeta describes a meta tag as "name: content"
+func htmlMeta(node *html.Node) string {
+	if charset := htmlAttr(node, "charset"); charset != "" {
+		return "charset: " + charset
+	}
+	name := firstNonEmpty(htmlAttr(node, "name"), htmlAttr(node, "property"), htmlAttr(node, "http-equiv"), htmlAttr(node, "itemprop"))
+	if name == "" {
+		return ""
+	}
+	return name + ": " + htmlAttr(node, "content")
+}
+
+// htmlScript describes a script element by its source and loading attributes,
+// or by its type and size when inline
+func htmlScript(node *html.Node) string {
+	var markers []string
+	if scriptType := htmlAttr(node, "type"); scriptType != "" && scriptType != "text/javascript" {
+		markers = append(markers, scriptType)
+	}
+	for _, name := range []string{"async", "defer", "nomodule"} {
+		if htmlHasAttr(node, name) {
+			markers = append(markers, name)
+		}
+	}
+
+	script := htmlAttr(node, "src")
+	if script == "" {
+		script = "inline (" + htmlLines(node) + ")"
+	}
+	if len(markers) > 0 {
+		script += " [" + strings.Join(markers, ", ") + "]"
+	}
+	return script
+}
+
+// htmlForm describes a form as "METHOD action (#id): field, field"
+func htmlForm(node *html.Node) string {
+	method := strings.ToUpper(firstNonEmpty(htmlAttr(node, "method"), "get"))
+	form := method + " " + firstNonEmpty(htmlAttr(node, "action"), "(same page)")
+	if id := firstNonEmpty(htmlAttr(node, "id"), htmlAttr(node, "name")); id != "" {
+		form += " (#" + id + ")"
+	}
+
+	var fields []string
+	var collect func(node *html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch node.DataAtom {
+			case atom.Input, atom.Select, atom.Textarea, atom.Button:
+				if name := htmlAttr(node, "name"); name != "" {
+					fields = append(fields, name)
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(node)
+
+	if fields = uniqueStrings(fields); len(fields) > 0 {
+		form += ": " + strings.Join(fields, ", ")
+	}
+	return form
+}
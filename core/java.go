@@ -0,0 +1,129 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Java declaration patterns, applied to comment-stripped lines
+var (
+	javaPackagePattern     = regexp.MustCompile(`^package\s+([\w.]+)\s*;`)
+	javaImportPattern      = regexp.MustCompile(`^import\s+(static\s+)?([\w.*]+)\s*;`)
+	javaAnnotationPattern  = regexp.MustCompile(`^@(\w+(?:\.\w+)*)(\s*\([^)]*\))?\s*`)
+	javaTypePattern        = regexp.MustCompile(`^((?:(?:public|private|protected|static|final|abstract|sealed|non-sealed|strictfp)\s+)*)(class|interface|enum|record|@interface)\s+(\w+)`)
+	javaMethodPattern      = regexp.MustCompile(`^((?:(?:public|private|protected|static|final|abstract|synchronized|native|default|strictfp)\s+)*)(?:<[^>]+>\s*)?([\w.$]+(?:<.*>)?(?:\[\])*)\s+(\w+)\s*\(`)
+	javaConstructorPattern = regexp.MustCompile(`^((?:(?:public|private|protected)\s+)*)(\w+)\s*\(`)
+)
+
+// javaNonTypes are words that can precede an identifier and "(" in statements
+// but are never a method's return type
+var javaNonTypes = map[string]bool{
+	"return": true, "new": true, "throw": true, "else": true, "case": true, "yield": true,
+}
+
+// parseJavaFile extracts the package, imports, types, methods and annotations
+// from Java source, tracking braces to find each method's owning class
+func (s *Summarizer) parseJavaFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(stripCComments(string(content)), "\n")
+	summary.LineCount = len(lines)
+
+	var scopes scopeStack
+	annotationCounts := make(map[string]int)
+	var annotationOrder []string
+	depth := 0
+
+	// Methods and constructors are declared directly inside a type body
+	addMember := func(line string, lineDepth int) {
+		owner, ok := scopes.current()
+		if !ok || !owner.opened || lineDepth != owner.depth+1 {
+			return
+		}
+		fallback := "~ "
+		if owner.kind == "interface" || owner.kind == "@interface" {
+			fallback = "+ "
+		}
+
+		simpleName := owner.name[strings.LastIndex(owner.name, ".")+1:]
+		if matches := javaConstructorPattern.FindStringSubmatch(line); matches != nil && matches[2] == simpleName {
+			summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], fallback)+matches[2])
+			summary.FunctionCount++
+		} else if matches := javaMethodPattern.FindStringSubmatch(line); matches != nil && !javaNonTypes[matches[2]] {
+			summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], fallback)+matches[3])
+			summary.FunctionCount++
+		}
+	}
+
+	for _, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		lineDepth := depth
+		depth += braceDelta(line)
+
+		if line == "" {
+			continue
+		}
+
+		if matches := javaPackagePattern.FindStringSubmatch(line); matches != nil {
+			summary.Package = matches[1]
+			continue
+		}
+
+		if matches := javaImportPattern.FindStringSubmatch(line); matches != nil {
+			summary.Imports = append(summary.Imports, matches[1]+matches[2])
+			continue
+		}
+
+		// Peel off leading annotations so the declaration they decorate is matched
+		for {
+			matches := javaAnnotationPattern.FindStringSubmatch(line)
+			if matches == nil || matches[1] == "interface" {
+				break
+			}
+			name := "@" + matches[1]
+			if annotationCounts[name] == 0 {
+				annotationOrder = append(annotationOrder, name)
+			}
+			annotationCounts[name]++
+			line = line[len(matches[0]):]
+		}
+
+		if matches := javaTypePattern.FindStringSubmatch(line); matches != nil {
+			kind := matches[2]
+			fallback := "~ "
+			if owner, ok := scopes.current(); ok && owner.kind == "interface" {
+				fallback = "+ "
+			}
+			name := scopes.qualifiedName(matches[3])
+			entry := accessMarker(matches[1], fallback) + name
+
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", entry, kind))
+			switch kind {
+			case "class", "record":
+				summary.Structs = append(summary.Structs, entry)
+			case "interface", "@interface":
+				summary.Interfaces = append(summary.Interfaces, entry)
+			}
+
+			scopes = scopes.push(name, kind, lineDepth, strings.Contains(line, "{"))
+			// Members of a body written on the declaration line
+			for _, member := range bodyMembers(line) {
+				addMember(member, lineDepth+1)
+			}
+			scopes = scopes.update(depth)
+			continue
+		}
+
+		addMember(line, lineDepth)
+		scopes = scopes.update(depth)
+	}
+
+	summary.Annotations = formatCounts(annotationOrder, annotationCounts)
+	return summary
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// kotlinModifiers is the alternation of Kotlin declaration modifiers
+const kotlinModifiers = `public|private|protected|internal|open|abstract|final|override|suspend|inline|noinline|crossinline|data|sealed|enum|annotation|inner|value|operator|infix|tailrec|external|const|lateinit|expect|actual|companion`
+
+// Kotlin declaration patterns, applied to comment-stripped lines
+var (
+	kotlinPackagePattern    = regexp.MustCompile(`^package\s+([\w.]+)`)
+	kotlinImportPattern     = regexp.MustCompile(`^import\s+([\w.*]+)(?:\s+as\s+(\w+))?`)
+	kotlinAnnotationPattern = regexp.MustCompile(`^@(?:\w+:)?([\w.]+)(?:\([^)]*\))?\s*`)
+	kotlinTypePattern       = regexp.MustCompile(`^((?:(?:` + kotlinModifiers + `)\s+)*)(class|interface|object|fun\s+interface)\b\s*(\w+)?`)
+	kotlinFunctionPattern   = regexp.MustCompile(`^((?:(?:` + kotlinModifiers + `)\s+)*)fun\s+(?:<[^>]*>\s*)?(?:([\w.<>?, *]+)\.)?(\w+|` + "`[^`]+`" + `)\s*\(`)
+)
+
+// parseKotlinFile extracts the package, imports, classes, objects, interfaces
+// and functions (including extension functions) from Kotlin source
+func (s *Summarizer) parseKotlinFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(stripCComments(string(content)), "\n")
+	summary.LineCount = len(lines)
+
+	var scopes scopeStack
+	annotationCounts := make(map[string]int)
+	var annotationOrder []string
+	depth := 0
+
+	// addFunction records a member of the enclosing type or a top-level function
+	addFunction := func(line string, lineDepth int) {
+		matches := kotlinFunctionPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return
+		}
+		name := matches[3]
+		if matches[2] != "" {
+			name = matches[2] + "." + name
+		}
+		entry := accessMarker(matches[1], "+ ") + name
+		if matches[2] != "" {
+			entry += " (extension)"
+		}
+
+		if owner, ok := scopes.current(); ok && owner.opened && lineDepth == owner.depth+1 {
+			summary.Members[owner.name] = append(summary.Members[owner.name], entry)
+			summary.FunctionCount++
+		} else if !ok && lineDepth == 0 {
+			summary.Functions = append(summary.Functions, entry)
+			summary.FunctionCount++
+		}
+	}
+
+	for i, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		lineDepth := depth
+		depth += braceDelta(line)
+
+		if line == "" {
+			continue
+		}
+
+		if matches := kotlinPackagePattern.FindStringSubmatch(line); matches != nil {
+			summary.Package = matches[1]
+			continue
+		}
+
+		if matches := kotlinImportPattern.FindStringSubmatch(line); matches != nil {
+			imp := matches[1]
+			if matches[2] != "" {
+				imp += " as " + matches[2]
+			}
+			summary.Imports = append(summary.Imports, imp)
+			continue
+		}
+
+		for {
+			matches := kotlinAnnotationPattern.FindStringSubmatch(line)
+			if matches == nil {
+				break
+			}
+			name := "@" + matches[1]
+			if annotationCounts[name] == 0 {
+				annotationOrder = append(annotationOrder, name)
+			}
+			annotationCounts[name]++
+			line = line[len(matches[0]):]
+		}
+
+		if matches := kotlinTypePattern.FindStringSubmatch(line); matches != nil {
+			kind := kotlinTypeKind(matches[1], matches[2])
+			name := matches[3]
+			if name == "" && kind == "companion object" {
+				// Unnamed companion objects are referred to as Companion
+				name = "Companion"
+			}
+			if name == "" {
+				// Anonymous object expression
+				scopes = scopes.update(depth)
+				continue
+			}
+			name = scopes.qualifiedName(name)
+			entry := accessMarker(matches[1], "+ ") + name
+
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", entry, kind))
+			switch {
+			case strings.HasSuffix(kind, "interface"):
+				summary.Interfaces = append(summary.Interfaces, entry)
+			case strings.HasSuffix(kind, "class") && kind != "enum class" && kind != "annotation class":
+				summary.Structs = append(summary.Structs, entry)
+			}
+
+			if declarationHasBody(lines, i) {
+				scopes = scopes.push(name, kind, lineDepth, strings.Contains(line, "{"))
+				// Members of a body written on the declaration line
+				for _, member := range bodyMembers(line) {
+					addFunction(member, lineDepth+1)
+				}
+			}
+			scopes = scopes.update(depth)
+			continue
+		}
+
+		addFunction(line, lineDepth)
+		scopes = scopes.update(depth)
+	}
+
+	summary.Annotations = formatCounts(annotationOrder, annotationCounts)
+	return summary
+}
+
+// kotlinTypeKind combines kind-changing modifiers with the declaration keyword,
+// e.g. "data class", "enum class" or "companion object"
+func kotlinTypeKind(modifiers, keyword string) string {
+	keyword = normalizeSpace(keyword)
+	for _, modifier := range strings.Fields(modifiers) {
+		switch modifier {
+		case "data", "enum", "sealed", "annotation", "value", "inner", "companion", "abstract":
+			return modifier + " " + keyword
+		}
+	}
+	return keyword
+}
@@ -0,0 +1,754 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// LockSummary describes the packages resolved by a lockfile
+type LockSummary struct {
+	Format     string   // npm, Yarn, Cargo, Poetry or Go
+	Packages   int      // Resolved packages, counting each version separately except in go.sum
+	Direct     int      // Packages the project depends on directly
+	Duplicates []string // Packages resolved at more than one version
+	Transitive []string // Indirect packages and the direct dependencies that pull them in
+	Sources    []string // Packages resolved from git, URLs or local paths instead of a registry
+	Note       string   // Caveat about what the lockfile cannot tell
+}
+
+// lockPackage is one resolved package in a lockfile dependency graph
+type lockPackage struct {
+	name    string
+	version string
+	source  string // Non-registry source, e.g. "git https://..."; empty for registry packages
+	deps    []int  // Indexes of the packages this one depends on
+}
+
+// lockGraph is the dependency graph read from a lockfile
+type lockGraph struct {
+	packages []lockPackage
+	direct   []int // Indexes of direct dependencies
+	linked   bool  // Whether the lockfile records dependency edges at all
+}
+
+// roots returns the packages that nothing else depends on, which stand in for
+// the direct dependencies when the project manifest is not available
+func (g *lockGraph) roots() []int {
+	required := make(map[int]bool)
+	for _, pkg := range g.packages {
+		for _, dep := range pkg.deps {
+			required[dep] = true
+		}
+	}
+	var roots []int
+	for i := range g.packages {
+		if !required[i] {
+			roots = append(roots, i)
+		}
+	}
+	return roots
+}
+
+// add appends a package and returns its index
+func (g *lockGraph) add(pkg lockPackage) int {
+	g.packages = append(g.packages, pkg)
+	return len(g.packages) - 1
+}
+
+// parseLockfile reads any supported lockfile into a dependency graph and
+// summarizes it
+func (s *Summarizer) parseLockfile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var graph *lockGraph
+	var format string
+	switch name := filepath.Base(fullPath); name {
+	case "package-lock.json", "npm-shrinkwrap.json":
+		format = "npm"
+		graph, err = readNpmLock(content, filepath.Dir(fullPath))
+	case "yarn.lock":
+		format = "Yarn"
+		graph, err = readYarnLock(content, filepath.Dir(fullPath))
+	case "Cargo.lock":
+		format = "Cargo"
+		graph, err = readCargoLock(content)
+	case "poetry.lock":
+		format = "Poetry"
+		graph, err = readPoetryLock(content, filepath.Dir(fullPath))
+	case "go.sum":
+		format = "Go"
+		graph, err = readGoSum(content, filepath.Dir(fullPath))
+	default:
+		err = fmt.Errorf("unrecognized lockfile %s", name)
+	}
+	if err != nil {
+		summary.Error = fmt.Sprintf("Invalid lockfile: %v", err)
+		return summary
+	}
+
+	if len(graph.direct) == 0 && graph.linked {
+		graph.direct = graph.roots()
+	}
+	lock := summarizeLockGraph(graph)
+	lock.Format = format
+	if format == "Go" {
+		// go.sum also keeps checksums of versions that minimal version
+		// selection considered but did not pick, while a build uses one
+		// version per module, so extra versions are not duplicates
+		lock.Packages = countModulePaths(graph)
+		lock.Duplicates = nil
+		lock.Note = "go.sum records checksums only, including versions not selected by the build; run `go mod why` to trace indirect modules"
+	}
+	summary.Lock = lock
+	return summary
+}
+
+// summarizeLockGraph counts packages, finds duplicate versions and non-registry
+// sources, and traces each indirect package back to the direct dependencies
+// that require it
+func summarizeLockGraph(graph *lockGraph) *LockSummary {
+	lock := &LockSummary{Packages: len(graph.packages), Direct: len(graph.direct)}
+
+	// For every package, the direct dependencies it is reachable from
+	via := make([][]string, len(graph.packages))
+	isDirect := make(map[int]bool)
+	for _, root := range graph.direct {
+		isDirect[root] = true
+	}
+	for _, root := range graph.direct {
+		seen := map[int]bool{root: true}
+		queue := []int{root}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, dep := range graph.packages[current].deps {
+				if seen[dep] {
+					continue
+				}
+				seen[dep] = true
+				queue = append(queue, dep)
+				via[dep] = append(via[dep], graph.packages[root].name)
+			}
+		}
+	}
+
+	versions := make(map[string][]int)
+	var names []string
+	for i, pkg := range graph.packages {
+		if _, exists := versions[pkg.name]; !exists {
+			names = append(names, pkg.name)
+		}
+		versions[pkg.name] = append(versions[pkg.name], i)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		indexes := versions[name]
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return versionLess(graph.packages[indexes[a]].version, graph.packages[indexes[b]].version)
+		})
+
+		if len(indexes) > 1 {
+			var entries []string
+			for _, index := range indexes {
+				entry := graph.packages[index].version
+				if len(via[index]) > 0 {
+					entry += " via " + strings.Join(uniqueStrings(via[index]), ", ")
+				}
+				entries = append(entries, entry)
+			}
+			lock.Duplicates = append(lock.Duplicates, fmt.Sprintf("%s: %s", name, strings.Join(entries, "; ")))
+		}
+
+		for _, index := range indexes {
+			pkg := graph.packages[index]
+			if !isDirect[index] && len(via[index]) > 0 {
+				lock.Transitive = append(lock.Transitive, fmt.Sprintf("%s %s ← %s", pkg.name, pkg.version, strings.Join(uniqueStrings(via[index]), ", ")))
+			}
+			if pkg.source != "" {
+				lock.Sources = append(lock.Sources, fmt.Sprintf("%s %s: %s", pkg.name, pkg.version, pkg.source))
+			}
+		}
+	}
+	return lock
+}
+
+// npmLockPackage is an entry of package-lock.json "packages" (v2/v3) or
+// "dependencies" (v1)
+type npmLockPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Resolved             string            `json:"resolved"`
+	Link                 bool              `json:"link"`
+	Dev                  bool              `json:"dev"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	Requires             map[string]string `json:"requires"`
+}
+
+// readNpmLock builds the graph of a package-lock.json, resolving each
+// dependency the way Node does: the nearest node_modules folder wins
+func readNpmLock(content []byte, dir string) (*lockGraph, error) {
+	var lockfile struct {
+		LockfileVersion int                        `json:"lockfileVersion"`
+		Packages        map[string]npmLockPackage  `json:"packages"`
+		Dependencies    map[string]json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lockfile); err != nil {
+		return nil, err
+	}
+
+	packages := lockfile.Packages
+	if packages == nil {
+		// Version 1 lockfiles nest dependencies instead of listing install
+		// paths, and leave the direct dependencies to package.json
+		var root npmLockPackage
+		if manifest, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+			json.Unmarshal(manifest, &root)
+		}
+		packages = map[string]npmLockPackage{"": root}
+		if err := flattenNpmV1(lockfile.Dependencies, "", packages); err != nil {
+			return nil, err
+		}
+	}
+
+	graph := &lockGraph{linked: true}
+	indexes := make(map[string]int)
+	paths := sortedKeys(packages)
+	for _, installPath := range paths {
+		pkg := packages[installPath]
+		if installPath == "" || pkg.Link {
+			continue
+		}
+		name := firstNonEmpty(pkg.Name, npmPackageName(installPath))
+		source := classifySource(pkg.Resolved)
+		if !strings.HasPrefix(installPath, "node_modules/") {
+			source = "local " + installPath // Workspace member
+		}
+		indexes[installPath] = graph.add(lockPackage{name: name, version: pkg.Version, source: source})
+	}
+	// Workspace links in node_modules point at the member's folder
+	for _, installPath := range paths {
+		if pkg := packages[installPath]; pkg.Link {
+			if target, exists := indexes[pkg.Resolved]; exists {
+				indexes[installPath] = target
+			}
+		}
+	}
+
+	// resolve finds the install path that satisfies name when required from installPath
+	resolve := func(installPath, name string) (int, bool) {
+		for dir := installPath; ; {
+			candidate := path.Join(dir, "node_modules", name)
+			if dir == "" {
+				candidate = "node_modules/" + name
+			}
+			if index, exists := indexes[candidate]; exists {
+				return index, true
+			}
+			if dir == "" {
+				return 0, false
+			}
+			// Move up past the enclosing node_modules folder
+			parent := strings.LastIndex(dir, "node_modules/")
+			if parent <= 0 {
+				dir = ""
+			} else {
+				dir = strings.TrimSuffix(dir[:parent], "/")
+			}
+		}
+	}
+
+	for _, installPath := range paths {
+		pkg := packages[installPath]
+		if pkg.Link {
+			continue
+		}
+		var names []string
+		for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies, pkg.PeerDependencies, pkg.Requires} {
+			names = append(names, sortedKeys(deps)...)
+		}
+		if installPath == "" {
+			names = append(names, sortedKeys(pkg.DevDependencies)...)
+		}
+		for _, name := range uniqueStrings(names) {
+			index, found := resolve(installPath, name)
+			if !found {
+				continue
+			}
+			if installPath == "" {
+				graph.direct = append(graph.direct, index)
+			} else {
+				current := &graph.packages[indexes[installPath]]
+				current.deps = append(current.deps, index)
+			}
+		}
+	}
+	graph.direct = uniqueInts(graph.direct)
+	return graph, nil
+}
+
+// flattenNpmV1 converts the nested "dependencies" of a version 1 lockfile into
+// install paths like those of later versions
+func flattenNpmV1(dependencies map[string]json.RawMessage, prefix string, packages map[string]npmLockPackage) error {
+	for name, raw := range dependencies {
+		var entry struct {
+			npmLockPackage
+			Dependencies map[string]json.RawMessage `json:"dependencies"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		installPath := path.Join(prefix, "node_modules", name)
+		pkg := entry.npmLockPackage
+		pkg.Dependencies = nil
+		packages[installPath] = pkg
+		if err := flattenNpmV1(entry.Dependencies, installPath, packages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// npmPackageName returns the package name at the end of an install path,
+// keeping the scope of scoped packages
+func npmPackageName(installPath string) string {
+	index := strings.LastIndex(installPath, "node_modules/")
+	if index < 0 {
+		// Workspace folders are named by their path
+		return installPath
+	}
+	return installPath[index+len("node_modules/"):]
+}
+
+// classifySource labels git, local and plain URL sources, returning "" for
+// registry downloads, which are identified by the "/-/" tarball path segment
+// or a registry scheme
+func classifySource(resolved string) string {
+	switch {
+	case resolved == "":
+		return ""
+	case strings.HasPrefix(resolved, "git+") || strings.HasPrefix(resolved, "git:") ||
+		strings.HasPrefix(resolved, "github:") || strings.HasPrefix(resolved, "gitlab:") ||
+		strings.HasPrefix(resolved, "bitbucket:") || strings.Contains(resolved, ".git#"):
+		return "git " + strings.TrimPrefix(resolved, "git+")
+	case strings.HasPrefix(resolved, "file:") || strings.HasPrefix(resolved, "link:") ||
+		strings.HasPrefix(resolved, "portal:") || strings.HasPrefix(resolved, "workspace:"):
+		return "local " + resolved
+	case strings.HasPrefix(resolved, "http://") || strings.HasPrefix(resolved, "https://"):
+		if strings.Contains(resolved, "/-/") {
+			return ""
+		}
+		return "url " + resolved
+	}
+	return ""
+}
+
+// yarnEntry is one block of a yarn.lock file
+type yarnEntry struct {
+	specs    []string          // Requested ranges, e.g. "lodash@^4.17.0"
+	version  string            // Resolved version
+	resolved string            // Download URL (v1) or resolution (berry)
+	deps     map[string]string // Dependency name to requested range
+}
+
+// readYarnLock builds the graph of a yarn.lock in either the classic (v1)
+// or the YAML-based berry format. Direct dependencies come from the
+// package.json next to it.
+func readYarnLock(content []byte, dir string) (*lockGraph, error) {
+	var entries []*yarnEntry
+	var current *yarnEntry
+	inDependencies := false
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		switch {
+		case indent == 0:
+			current = nil
+			header := strings.TrimSuffix(trimmed, ":")
+			if header == "__metadata" {
+				continue
+			}
+			current = &yarnEntry{deps: make(map[string]string)}
+			for _, spec := range strings.Split(header, ",") {
+				current.specs = append(current.specs, strings.Trim(strings.TrimSpace(spec), `"`))
+			}
+			entries = append(entries, current)
+			inDependencies = false
+
+		case current == nil:
+			continue
+
+		case indent == 2:
+			key, value := yarnField(trimmed)
+			inDependencies = key == "dependencies" || key == "optionalDependencies"
+			switch key {
+			case "version":
+				current.version = value
+			case "resolved", "resolution":
+				current.resolved = value
+			}
+
+		case indent >= 4 && inDependencies:
+			key, value := yarnField(trimmed)
+			current.deps[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	graph := &lockGraph{linked: true}
+	bySpec := make(map[string]int)
+	for _, entry := range entries {
+		name := yarnSpecName(entry.specs[0])
+		source := classifySource(entry.resolved)
+		// Berry resolutions name the protocol, e.g. "pkg@git+https://..." or "pkg@workspace:."
+		if _, protocol, found := strings.Cut(strings.TrimPrefix(entry.resolved, "@"), "@"); found && !strings.HasPrefix(protocol, "npm:") {
+			source = classifySource(protocol)
+		}
+		index := graph.add(lockPackage{name: name, version: entry.version, source: source})
+		for _, spec := range entry.specs {
+			bySpec[spec] = index
+		}
+	}
+
+	lookup := func(name, spec string) (int, bool) {
+		for _, candidate := range []string{name + "@" + spec, name + "@npm:" + spec} {
+			if index, exists := bySpec[candidate]; exists {
+				return index, true
+			}
+		}
+		return 0, false
+	}
+	for i, entry := range entries {
+		for _, name := range sortedKeys(entry.deps) {
+			if index, found := lookup(name, entry.deps[name]); found {
+				graph.packages[i].deps = append(graph.packages[i].deps, index)
+			}
+		}
+	}
+
+	// Direct dependencies are the ranges requested by package.json
+	if manifest, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg packageJSON
+		if json.Unmarshal(manifest, &pkg) == nil {
+			for _, raw := range []json.RawMessage{pkg.Dependencies, pkg.DevDependencies, pkg.OptionalDependencies} {
+				var deps map[string]string
+				if json.Unmarshal(raw, &deps) != nil {
+					continue
+				}
+				for _, name := range sortedKeys(deps) {
+					if index, found := lookup(name, deps[name]); found {
+						graph.direct = append(graph.direct, index)
+					}
+				}
+			}
+		}
+	}
+	return graph, nil
+}
+
+// yarnField splits a yarn.lock field in either `key "value"` or `key: value` form
+func yarnField(line string) (string, string) {
+	key, value, found := strings.Cut(line, ": ")
+	if !found {
+		key, value, _ = strings.Cut(line, " ")
+	}
+	key = strings.TrimSuffix(strings.Trim(key, `"`), ":")
+	return key, strings.Trim(strings.TrimSpace(value), `"`)
+}
+
+// yarnSpecName returns the package name of a "name@range" spec, allowing for
+// scoped names such as "@types/node@^20"
+func yarnSpecName(spec string) string {
+	if index := strings.LastIndex(spec, "@"); index > 0 {
+		return spec[:index]
+	}
+	return spec
+}
+
+// readCargoLock builds the graph of a Cargo.lock. Packages without a source
+// are workspace members, and their dependencies are the direct ones.
+func readCargoLock(content []byte) (*lockGraph, error) {
+	var lockfile struct {
+		Package []struct {
+			Name         string   `toml:"name"`
+			Version      string   `toml:"version"`
+			Source       string   `toml:"source"`
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"package"`
+	}
+	if _, err := toml.Decode(string(content), &lockfile); err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimPrefix(err.Error(), "toml: "))
+	}
+
+	graph := &lockGraph{linked: true}
+	byName := make(map[string][]int)
+	var members []int
+	for _, pkg := range lockfile.Package {
+		source := ""
+		if strings.HasPrefix(pkg.Source, "git+") {
+			source = "git " + strings.TrimPrefix(pkg.Source, "git+")
+		} else if pkg.Source != "" && !strings.HasPrefix(pkg.Source, "registry+") && !strings.HasPrefix(pkg.Source, "sparse+") {
+			source = pkg.Source
+		}
+		index := graph.add(lockPackage{name: pkg.Name, version: pkg.Version, source: source})
+		byName[pkg.Name] = append(byName[pkg.Name], index)
+		if pkg.Source == "" {
+			members = append(members, index)
+		}
+	}
+
+	// Dependencies are "name", or "name version" when several versions exist
+	for i, pkg := range lockfile.Package {
+		for _, dependency := range pkg.Dependencies {
+			fields := strings.Fields(dependency)
+			for _, candidate := range byName[fields[0]] {
+				if len(fields) == 1 || graph.packages[candidate].version == fields[1] {
+					graph.packages[i].deps = append(graph.packages[i].deps, candidate)
+					break
+				}
+			}
+		}
+	}
+
+	isMember := make(map[int]bool)
+	for _, member := range members {
+		isMember[member] = true
+	}
+	for _, member := range members {
+		for _, dep := range graph.packages[member].deps {
+			if !isMember[dep] {
+				graph.direct = append(graph.direct, dep)
+			}
+		}
+	}
+	graph.direct = uniqueInts(graph.direct)
+	return graph, nil
+}
+
+// readPoetryLock builds the graph of a poetry.lock, taking direct dependencies
+// from the pyproject.toml next to it
+func readPoetryLock(content []byte, dir string) (*lockGraph, error) {
+	var lockfile struct {
+		Package []struct {
+			Name         string                 `toml:"name"`
+			Version      string                 `toml:"version"`
+			Dependencies map[string]interface{} `toml:"dependencies"`
+			Source       struct {
+				Type      string `toml:"type"`
+				URL       string `toml:"url"`
+				Reference string `toml:"reference"`
+			} `toml:"source"`
+		} `toml:"package"`
+	}
+	if _, err := toml.Decode(string(content), &lockfile); err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimPrefix(err.Error(), "toml: "))
+	}
+
+	graph := &lockGraph{linked: true}
+	byName := make(map[string]int)
+	for _, pkg := range lockfile.Package {
+		source := ""
+		switch pkg.Source.Type {
+		case "git":
+			source = strings.TrimSpace("git " + pkg.Source.URL + " " + pkg.Source.Reference)
+		case "url":
+			source = "url " + pkg.Source.URL
+		case "directory", "file":
+			source = "local " + pkg.Source.URL
+		}
+		byName[normalizePythonName(pkg.Name)] = graph.add(lockPackage{name: pkg.Name, version: pkg.Version, source: source})
+	}
+	for i, pkg := range lockfile.Package {
+		for _, name := range sortedKeys(pkg.Dependencies) {
+			if index, exists := byName[normalizePythonName(name)]; exists {
+				graph.packages[i].deps = append(graph.packages[i].deps, index)
+			}
+		}
+	}
+
+	if manifest, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+		var pyproject map[string]interface{}
+		if _, err := toml.Decode(string(manifest), &pyproject); err == nil {
+			for _, name := range pythonDependencyNames(pyproject) {
+				if index, exists := byName[name]; exists {
+					graph.direct = append(graph.direct, index)
+				}
+			}
+		}
+	}
+	graph.direct = uniqueInts(graph.direct)
+	return graph, nil
+}
+
+// pythonDependencyNames lists the normalized names of every dependency
+// declared in a pyproject.toml, in PEP 621 or Poetry form
+func pythonDependencyNames(pyproject map[string]interface{}) []string {
+	var requirements []string
+	project := tomlTable(pyproject, "project")
+	requirements = append(requirements, pythonRequirements(project["dependencies"], "")...)
+	for _, group := range tomlTable(project, "optional-dependencies") {
+		requirements = append(requirements, pythonRequirements(group, "")...)
+	}
+	for _, group := range tomlTable(pyproject, "dependency-groups") {
+		requirements = append(requirements, pythonRequirements(group, "")...)
+	}
+
+	var names []string
+	for _, requirement := range requirements {
+		if matches := pep508Pattern.FindStringSubmatch(requirement); matches != nil {
+			names = append(names, normalizePythonName(matches[1]))
+		}
+	}
+
+	poetry := tomlTable(pyproject, "tool", "poetry")
+	tables := []map[string]interface{}{tomlTable(poetry, "dependencies"), tomlTable(poetry, "dev-dependencies")}
+	for _, group := range tomlTable(poetry, "group") {
+		if group, ok := group.(map[string]interface{}); ok {
+			tables = append(tables, tomlTable(group, "dependencies"))
+		}
+	}
+	for _, table := range tables {
+		for name := range table {
+			if name != "python" {
+				names = append(names, normalizePythonName(name))
+			}
+		}
+	}
+	sort.Strings(names)
+	return uniqueStrings(names)
+}
+
+// normalizePythonName normalizes a distribution name as described in PEP 503
+func normalizePythonName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
+
+// readGoSum lists the module versions in a go.sum. go.sum records no
+// dependency edges, so only the direct requirements of go.mod are marked.
+func readGoSum(content []byte, dir string) (*lockGraph, error) {
+	graph := &lockGraph{}
+	indexes := make(map[string]int)
+	for number, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected module, version and hash", number+1)
+		}
+		// Entries for only the go.mod file belong to modules whose code is
+		// never downloaded, so they do not count as resolved packages
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		key := fields[0] + "@" + fields[1]
+		if _, exists := indexes[key]; !exists {
+			indexes[key] = graph.add(lockPackage{name: fields[0], version: fields[1]})
+		}
+	}
+
+	if manifest, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+		if file, err := parseModFile("go.mod", manifest); err == nil {
+			required := make(map[string]string)
+			for _, require := range file.Require {
+				required[require.Mod.Path] = require.Mod.Version
+			}
+			for _, replace := range file.Replace {
+				if replace.New.Version != "" {
+					continue
+				}
+				// Modules replaced by a local directory have no checksum
+				// of their own, so they are added from go.mod
+				source := "local " + replace.New.Path
+				found := false
+				for key, index := range indexes {
+					if strings.HasPrefix(key, replace.Old.Path+"@") {
+						graph.packages[index].source = source
+						found = true
+					}
+				}
+				if version, exists := required[replace.Old.Path]; exists && !found {
+					indexes[replace.Old.Path+"@"+version] = graph.add(lockPackage{name: replace.Old.Path, version: version, source: source})
+				}
+			}
+			for _, require := range file.Require {
+				if index, exists := indexes[require.Mod.Path+"@"+require.Mod.Version]; exists && !require.Indirect {
+					graph.direct = append(graph.direct, index)
+				}
+			}
+		}
+	}
+	return graph, nil
+}
+
+// countModulePaths counts the distinct package names in a graph
+func countModulePaths(graph *lockGraph) int {
+	paths := make(map[string]bool)
+	for _, pkg := range graph.packages {
+		paths[pkg.name] = true
+	}
+	return len(paths)
+}
+
+// versionLess orders version strings part by part, comparing numeric parts as
+// numbers so that 1.10.0 sorts after 1.9.0
+func versionLess(a, b string) bool {
+	split := func(version string) []string {
+		return strings.FieldsFunc(strings.TrimPrefix(version, "v"), func(r rune) bool {
+			return r == '.' || r == '-' || r == '+'
+		})
+	}
+	partsA, partsB := split(a), split(b)
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil && numberA != numberB:
+			return numberA < numberB
+		case (errA != nil || errB != nil) && partsA[i] != partsB[i]:
+			return partsA[i] < partsB[i]
+		}
+	}
+	return len(partsA) < len(partsB)
+}
+
+// uniqueInts removes repeated entries, keeping the first occurrence
+func uniqueInts(items []int) []int {
+	seen := make(map[int]bool)
+	var unique []int
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
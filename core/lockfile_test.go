@@ -0,0 +1,53 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoSum(t *testing.T) {
+	dir := t.TempDir()
+	goMod := "module example.com/x\n\ngo 1.22\n\nrequire (\n\texample.com/a v1.0.0\n\texample.com/b v1.10.0\n)\n\nreplace example.com/a => ../a\n"
+	goSum := "example.com/b v1.2.0 h1:aaa=\nexample.com/b v1.2.0/go.mod h1:bbb=\nexample.com/b v1.10.0 h1:ccc=\nexample.com/b v1.10.0/go.mod h1:ddd=\n"
+	for name, content := range map[string]string{"go.mod": goMod, "go.sum": goSum} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary := NewSummarizer(dir).SummarizeFile("go.sum")
+	lock := summary.Lock
+	if lock == nil {
+		t.Fatalf("no lock summary, Error = %q", summary.Error)
+	}
+	if lock.Packages != 2 {
+		t.Errorf("Packages = %d, want 2", lock.Packages)
+	}
+	if lock.Direct != 2 {
+		t.Errorf("Direct = %d, want 2", lock.Direct)
+	}
+	assertStrings(t, "Duplicates", lock.Duplicates, nil)
+	assertStrings(t, "Sources", lock.Sources, []string{"example.com/a v1.0.0: local ../a"})
+}
+
+func TestParseNpmLockDuplicates(t *testing.T) {
+	content := `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"dependencies": {"a": "^1.0.0", "b": "^1.0.0"}},
+    "node_modules/a": {"version": "1.0.0", "dependencies": {"c": "^1.0.0"}},
+    "node_modules/b": {"version": "1.0.0", "dependencies": {"c": "^2.0.0"}},
+    "node_modules/c": {"version": "1.2.0"},
+    "node_modules/b/node_modules/c": {"version": "2.0.0"}
+  }
+}`
+	summary := summarizeContent(t, "package-lock.json", content)
+	if summary.Lock == nil {
+		t.Fatalf("no lock summary, Error = %q", summary.Error)
+	}
+	if summary.Lock.Packages != 4 {
+		t.Errorf("Packages = %d, want 4", summary.Lock.Packages)
+	}
+	assertStrings(t, "Duplicates", summary.Lock.Duplicates, []string{"c: 1.2.0 via a; 2.0.0 via b"})
+}
@@ -0,0 +1,449 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogSummary describes the entries of a log file
+type LogSummary struct {
+	Format       string      // JSON lines, logfmt, syslog, Apache/nginx access or plain text
+	Entries      int         // Log entries, excluding continuation lines
+	Levels       []string    // Entry counts by level, most severe first
+	First        time.Time   // Timestamp of the first timestamped entry
+	Last         time.Time   // Timestamp of the last timestamped entry
+	Histogram    []LogBucket // Entries per time bucket between First and Last
+	BucketSize   string      // Width of each histogram bucket, e.g. "15m"
+	RecentErrors []string    // Most recent error lines, oldest first
+}
+
+// LogBucket counts the entries logged in one histogram interval
+type LogBucket struct {
+	Start time.Time
+	Count int
+}
+
+// Log formats recognized by detectLogFormat
+const (
+	logFormatJSON   = "JSON lines"
+	logFormatLogfmt = "logfmt"
+	logFormatSyslog = "syslog"
+	logFormatAccess = "Apache/nginx access"
+	logFormatPlain  = "plain text"
+)
+
+const (
+	logSniffLines      = 50      // Lines used to detect the format
+	recentErrorLines   = 10      // Error lines shown from the end of the file
+	maxTailScanBytes   = 8 << 20 // How far back from the end to look for errors
+	tailChunkSize      = 64 << 10
+	maxHistogramBucket = 24
+	maxEpochSeconds    = 4102444800 // 2100-01-01T00:00:00Z, the latest epoch accepted as a timestamp
+	maxLogLineSize     = 4 << 20    // Longest line prefix that is parsed
+)
+
+// logLevels are the normalized levels, most severe first
+var logLevels = []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+
+// logLevelAliases normalizes level names found in the wild
+var logLevelAliases = map[string]string{
+	"fatal": "FATAL", "panic": "FATAL", "crit": "FATAL", "critical": "FATAL", "alert": "FATAL", "emerg": "FATAL", "emergency": "FATAL",
+	"error": "ERROR", "err": "ERROR", "severe": "ERROR",
+	"warn": "WARN", "warning": "WARN",
+	"info": "INFO", "notice": "INFO", "information": "INFO",
+	"debug": "DEBUG", "fine": "DEBUG",
+	"trace": "TRACE", "finest": "TRACE",
+}
+
+// syslogSeverities maps RFC 5424 severities (PRI mod 8) to levels
+var syslogSeverities = []string{"FATAL", "FATAL", "FATAL", "ERROR", "WARN", "INFO", "INFO", "DEBUG"}
+
+// histogramBuckets are the candidate bucket widths, smallest first
+var histogramBuckets = []struct {
+	size  time.Duration
+	label string
+}{
+	{time.Minute, "1m"}, {5 * time.Minute, "5m"}, {15 * time.Minute, "15m"}, {time.Hour, "1h"},
+	{6 * time.Hour, "6h"}, {24 * time.Hour, "1d"}, {7 * 24 * time.Hour, "7d"}, {30 * 24 * time.Hour, "30d"},
+}
+
+// Log line patterns
+var (
+	logfmtPairPattern   = regexp.MustCompile(`([\w.-]+)=("(?:[^"\\]|\\.)*"|\S*)`)
+	logfmtLinePattern   = regexp.MustCompile(`^(?:[\w.-]+=(?:"(?:[^"\\]|\\.)*"|\S*)\s*){2,}$`)
+	syslogPattern       = regexp.MustCompile(`^(?:<(\d{1,3})>)?([A-Z][a-z]{2}\s+\d{1,2} \d{2}:\d{2}:\d{2}) \S+ [^:\[]+(?:\[\d+\])?:`)
+	syslog5424Pattern   = regexp.MustCompile(`^<(\d{1,3})>1 (\S+) `)
+	accessLogPattern    = regexp.MustCompile(`^\S+ \S+ \S+ \[([^\]]+)\] "[^"]*" (\d{3}) `)
+	isoTimestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?`)
+	slashTimestamp      = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}`)
+	levelWordPattern    = regexp.MustCompile(`\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|ERR|SEVERE|FATAL|CRITICAL|CRIT|PANIC)\b|\[(?i:(trace|debug|info|notice|warn|warning|error|crit|alert|emerg|fatal))\]|\blevel=(\w+)`)
+)
+
+// isoTimestampLayouts parse the variants matched by isoTimestampPattern
+var isoTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseLogFile detects the log format, counts entries by level, builds a
+// time histogram and collects the most recent errors from the end of the file
+func (s *Summarizer) parseLogFile(fullPath string, summary FileSummary) FileSummary {
+	file, err := os.Open(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error opening file: %v", err)
+		return summary
+	}
+	defer file.Close()
+
+	head := make([]byte, 64<<10)
+	n, _ := io.ReadFull(file, head)
+	info := &LogSummary{Format: detectLogFormat(head[:n])}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	levelCounts := make(map[string]int)
+	perMinute := make(map[int64]int)
+	lineCount := 0
+
+	reader := bufio.NewReaderSize(file, 64<<10)
+	for {
+		line, err := readLogLine(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			summary.Error = fmt.Sprintf("Error reading file: %v", err)
+			return summary
+		}
+		lineCount++
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		// Indented lines continue the previous entry, e.g. stack traces
+		if info.Format == logFormatPlain && (line[0] == ' ' || line[0] == '\t') {
+			continue
+		}
+
+		info.Entries++
+		level, timestamp := parseLogLine(info.Format, line)
+		if level != "" {
+			levelCounts[level]++
+		}
+		if !timestamp.IsZero() {
+			if info.First.IsZero() {
+				info.First = timestamp
+			}
+			info.Last = timestamp
+			perMinute[timestamp.Unix()/60]++
+		}
+	}
+
+	summary.LineCount = lineCount
+	for _, level := range logLevels {
+		if levelCounts[level] > 0 {
+			info.Levels = append(info.Levels, fmt.Sprintf("%s: %d", level, levelCounts[level]))
+		}
+	}
+	info.Histogram, info.BucketSize = buildHistogram(info.First, info.Last, perMinute)
+	info.RecentErrors = recentErrors(file, info.Format)
+
+	summary.Log = info
+	return summary
+}
+
+// readLogLine reads the next line without its line ending. Only the first
+// maxLogLineSize bytes of longer lines are kept, so one huge line, such as a
+// dumped payload, cannot stop the rest of the file from being summarized.
+func readLogLine(reader *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if room := maxLogLineSize - len(line); room > 0 {
+			line = append(line, chunk[:min(len(chunk), room)]...)
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// detectLogFormat picks the format that matches most of the first lines
+func detectLogFormat(head []byte) string {
+	counts := make(map[string]int)
+	lines := bytes.Split(head, []byte("\n"))
+	if len(lines) > logSniffLines {
+		lines = lines[:logSniffLines]
+	}
+
+	for _, raw := range lines {
+		line := strings.TrimSpace(string(raw))
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "{") && json.Valid([]byte(line)):
+			counts[logFormatJSON]++
+		case accessLogPattern.MatchString(line):
+			counts[logFormatAccess]++
+		case syslogPattern.MatchString(line) || syslog5424Pattern.MatchString(line):
+			counts[logFormatSyslog]++
+		case logfmtLinePattern.MatchString(line):
+			counts[logFormatLogfmt]++
+		default:
+			counts[logFormatPlain]++
+		}
+	}
+
+	best, bestCount := logFormatPlain, 0
+	for _, format := range []string{logFormatJSON, logFormatAccess, logFormatSyslog, logFormatLogfmt, logFormatPlain} {
+		if counts[format] > bestCount {
+			best, bestCount = format, counts[format]
+		}
+	}
+	return best
+}
+
+// parseLogLine extracts the normalized level and timestamp of an entry; either
+// may be empty when the line does not carry it
+func parseLogLine(format, line string) (string, time.Time) {
+	switch format {
+	case logFormatJSON:
+		// Lines that are not objects, such as a banner or a stack trace
+		// between entries, are not worth decoding
+		var fields map[string]interface{}
+		if strings.HasPrefix(line, "{") && json.Unmarshal([]byte(line), &fields) == nil {
+			level := ""
+			for _, key := range []string{"level", "lvl", "severity", "loglevel", "log.level"} {
+				if value, ok := fields[key].(string); ok {
+					level = normalizeLogLevel(value)
+					break
+				}
+			}
+			for _, key := range []string{"time", "ts", "timestamp", "@timestamp", "t", "date"} {
+				if value, ok := fields[key]; ok {
+					return level, parseLogTimestamp(value)
+				}
+			}
+			return level, time.Time{}
+		}
+
+	case logFormatLogfmt:
+		level, timestamp := "", time.Time{}
+		for _, pair := range logfmtPairPattern.FindAllStringSubmatch(line, -1) {
+			value := strings.Trim(pair[2], `"`)
+			switch pair[1] {
+			case "level", "lvl", "severity":
+				level = normalizeLogLevel(value)
+			case "time", "ts", "t", "timestamp":
+				timestamp = parseLogTimestamp(value)
+			}
+		}
+		return level, timestamp
+
+	case logFormatSyslog:
+		if matches := syslog5424Pattern.FindStringSubmatch(line); matches != nil {
+			return syslogLevel(matches[1], line), parseLogTimestamp(matches[2])
+		}
+		if matches := syslogPattern.FindStringSubmatch(line); matches != nil {
+			timestamp, err := time.Parse(time.Stamp, strings.Join(strings.Fields(matches[2]), " "))
+			if err == nil {
+				// RFC 3164 timestamps have no year
+				timestamp = timestamp.AddDate(time.Now().Year(), 0, 0)
+			}
+			return syslogLevel(matches[1], line), timestamp
+		}
+
+	case logFormatAccess:
+		if matches := accessLogPattern.FindStringSubmatch(line); matches != nil {
+			timestamp, _ := time.Parse("02/Jan/2006:15:04:05 -0700", matches[1])
+			level := "INFO"
+			switch matches[2][0] {
+			case '5':
+				level = "ERROR"
+			case '4':
+				level = "WARN"
+			}
+			return level, timestamp
+		}
+	}
+
+	return plainLogLevel(line), plainLogTimestamp(line)
+}
+
+// syslogLevel derives the level from a <PRI> value, falling back to keywords
+func syslogLevel(priority, line string) string {
+	if value, err := strconv.Atoi(priority); err == nil {
+		return syslogSeverities[value%8]
+	}
+	return plainLogLevel(line)
+}
+
+// plainLogLevel finds a level keyword such as ERROR, [warn] or level=info
+func plainLogLevel(line string) string {
+	matches := levelWordPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return ""
+	}
+	return normalizeLogLevel(matches[1] + matches[2] + matches[3])
+}
+
+// plainLogTimestamp parses an ISO 8601 or nginx-style timestamp in a line
+func plainLogTimestamp(line string) time.Time {
+	if match := isoTimestampPattern.FindString(line); match != "" {
+		return parseLogTimestamp(strings.Replace(match, ",", ".", 1))
+	}
+	if match := slashTimestamp.FindString(line); match != "" {
+		timestamp, _ := time.Parse("2006/01/02 15:04:05", match)
+		return timestamp
+	}
+	return time.Time{}
+}
+
+// parseLogTimestamp parses string timestamps and Unix epochs in seconds or milliseconds
+func parseLogTimestamp(value interface{}) time.Time {
+	switch v := value.(type) {
+	case float64:
+		// Numbers outside 1970-2100 are more likely IDs or counters than
+		// timestamps, and would stretch the timeline over millennia
+		if v > 1e12 {
+			if v >= maxEpochSeconds*1e3 {
+				return time.Time{}
+			}
+			return time.UnixMilli(int64(v))
+		}
+		if v < 0 || v >= maxEpochSeconds {
+			return time.Time{}
+		}
+		return time.Unix(int64(v), int64((v-float64(int64(v)))*1e9))
+	case string:
+		for _, layout := range isoTimestampLayouts {
+			if timestamp, err := time.Parse(layout, v); err == nil {
+				return timestamp
+			}
+		}
+		if epoch, err := strconv.ParseFloat(v, 64); err == nil {
+			return parseLogTimestamp(epoch)
+		}
+	}
+	return time.Time{}
+}
+
+// normalizeLogLevel maps a level name to one of logLevels, or "" if unknown
+func normalizeLogLevel(level string) string {
+	return logLevelAliases[strings.ToLower(strings.TrimSpace(level))]
+}
+
+// buildHistogram groups per-minute counts into at most maxHistogramBucket
+// buckets of the smallest width that covers the time range
+func buildHistogram(first, last time.Time, perMinute map[int64]int) ([]LogBucket, string) {
+	if first.IsZero() || len(perMinute) == 0 {
+		return nil, ""
+	}
+
+	start, end := first.Unix()/60, last.Unix()/60
+	for minute := range perMinute {
+		if minute < start {
+			start = minute
+		}
+		if minute > end {
+			end = minute
+		}
+	}
+
+	bucket := histogramBuckets[len(histogramBuckets)-1]
+	for _, candidate := range histogramBuckets {
+		if (end-start)/int64(candidate.size/time.Minute) < maxHistogramBucket {
+			bucket = candidate
+			break
+		}
+	}
+
+	width := int64(bucket.size / time.Minute)
+	label := bucket.label
+	// Ranges longer than the widest bucket covers get wider buckets still,
+	// so the bucket count stays bounded however far apart the entries are
+	if (end-start)/width >= maxHistogramBucket {
+		width = ((end-start)/maxHistogramBucket/width + 1) * width
+		label = fmt.Sprintf("%dd", width/(24*60))
+	}
+	start -= start % width
+	buckets := make([]LogBucket, (end-start)/width+1)
+	for i := range buckets {
+		buckets[i].Start = time.Unix((start+int64(i)*width)*60, 0).In(first.Location())
+	}
+	for minute, count := range perMinute {
+		index := (minute - start) / width
+		if index >= 0 && index < int64(len(buckets)) {
+			buckets[index].Count += count
+		}
+	}
+	return buckets, label
+}
+
+// recentErrors reads the file backwards in chunks and returns the last
+// recentErrorLines error or fatal entries, oldest first
+func recentErrors(file *os.File, format string) []string {
+	info, err := file.Stat()
+	if err != nil {
+		return nil
+	}
+
+	var found []string
+	var partial []byte
+	offset := info.Size()
+	scanned := int64(0)
+
+	for offset > 0 && scanned < maxTailScanBytes && len(found) < recentErrorLines {
+		size := int64(tailChunkSize)
+		if offset < size {
+			size = offset
+		}
+		offset -= size
+		scanned += size
+
+		chunk := make([]byte, size, size+int64(len(partial)))
+		if _, err := file.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			break
+		}
+		chunk = append(chunk, partial...)
+
+		// The first line of a chunk may be incomplete unless this is the start of the file
+		lines := bytes.Split(chunk, []byte("\n"))
+		if offset > 0 {
+			partial = lines[0]
+			lines = lines[1:]
+		}
+		for i := len(lines) - 1; i >= 0 && len(found) < recentErrorLines; i-- {
+			line := strings.TrimRight(string(lines[i]), "\r")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if level, _ := parseLogLine(format, line); level == "ERROR" || level == "FATAL" {
+				found = append(found, line)
+			}
+		}
+	}
+
+	// Collected newest first
+	for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+		found[i], found[j] = found[j], found[i]
+	}
+	return found
+}
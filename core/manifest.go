@@ -0,0 +1,479 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/mod/modfile"
+)
+
+// ManifestSummary describes a project manifest such as go.mod or package.json
+type ManifestSummary struct {
+	Ecosystem       string   // Go, npm, Cargo or Python
+	Name            string   // Module or package name
+	Version         string   // Package version, when the manifest declares one
+	Runtime         string   // Language or runtime requirement, e.g. "go 1.24"
+	Dependencies    []string // Direct dependencies as "name version"
+	DevDependencies []string // Development, test and build dependencies
+	Indirect        int      // Indirect dependencies recorded in the manifest
+	Overrides       []string // replace directives, overrides, resolutions and patches
+	Scripts         []string // Scripts, binaries, tools and entry points
+}
+
+// pep508Pattern splits a Python requirement into name, extras and version specifier
+var pep508Pattern = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// parseGoMod summarizes a go.mod file with golang.org/x/mod/modfile
+func (s *Summarizer) parseGoMod(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	file, err := parseModFile(fullPath, content)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Invalid go.mod: %v", err)
+		return summary
+	}
+
+	manifest := &ManifestSummary{Ecosystem: "Go"}
+	if file.Module != nil {
+		manifest.Name = file.Module.Mod.Path
+	}
+	if file.Go != nil {
+		manifest.Runtime = "go " + file.Go.Version
+	}
+	if file.Toolchain != nil {
+		manifest.Runtime += fmt.Sprintf(" (toolchain %s)", file.Toolchain.Name)
+	}
+
+	for _, require := range file.Require {
+		if require.Indirect {
+			manifest.Indirect++
+			continue
+		}
+		manifest.Dependencies = append(manifest.Dependencies, require.Mod.Path+" "+require.Mod.Version)
+	}
+	for _, replace := range file.Replace {
+		old := strings.TrimSpace(replace.Old.Path + " " + replace.Old.Version)
+		replacement := strings.TrimSpace(replace.New.Path + " " + replace.New.Version)
+		manifest.Overrides = append(manifest.Overrides, fmt.Sprintf("%s => %s", old, replacement))
+	}
+	for _, exclude := range file.Exclude {
+		manifest.Overrides = append(manifest.Overrides, fmt.Sprintf("exclude %s %s", exclude.Mod.Path, exclude.Mod.Version))
+	}
+	for _, retract := range file.Retract {
+		versions := retract.Low
+		if retract.High != retract.Low {
+			versions = fmt.Sprintf("[%s, %s]", retract.Low, retract.High)
+		}
+		manifest.Overrides = append(manifest.Overrides, "retract "+versions)
+	}
+	for _, tool := range file.Tool {
+		manifest.Scripts = append(manifest.Scripts, "tool "+tool.Path)
+	}
+
+	summary.Manifest = manifest
+	return summary
+}
+
+// parseModFile parses a go.mod file strictly, so replace, exclude, toolchain
+// and tool directives are kept, falling back to lax parsing for files that
+// use directives newer than the modfile package knows
+func parseModFile(fullPath string, content []byte) (*modfile.File, error) {
+	file, err := modfile.Parse(fullPath, content, nil)
+	if err != nil {
+		return modfile.ParseLax(fullPath, content, nil)
+	}
+	return file, nil
+}
+
+// packageJSON holds the package.json fields shown in the summary. Objects are
+// kept raw so their keys can be listed in file order.
+type packageJSON struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	PackageManager       string            `json:"packageManager"`
+	Engines              map[string]string `json:"engines"`
+	Main                 string            `json:"main"`
+	Module               string            `json:"module"`
+	Bin                  json.RawMessage   `json:"bin"`
+	Scripts              json.RawMessage   `json:"scripts"`
+	Dependencies         json.RawMessage   `json:"dependencies"`
+	DevDependencies      json.RawMessage   `json:"devDependencies"`
+	PeerDependencies     json.RawMessage   `json:"peerDependencies"`
+	OptionalDependencies json.RawMessage   `json:"optionalDependencies"`
+	Overrides            json.RawMessage   `json:"overrides"`
+	Resolutions          json.RawMessage   `json:"resolutions"`
+	Pnpm                 struct {
+		Overrides json.RawMessage `json:"overrides"`
+	} `json:"pnpm"`
+}
+
+// parsePackageJSON summarizes an npm package.json
+func (s *Summarizer) parsePackageJSON(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var pkg packageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		summary.Error = fmt.Sprintf("Invalid JSON: %v", err)
+		return summary
+	}
+
+	manifest := &ManifestSummary{Ecosystem: "npm", Name: pkg.Name, Version: pkg.Version}
+	var runtimes []string
+	for _, engine := range sortedKeys(pkg.Engines) {
+		runtimes = append(runtimes, engine+" "+pkg.Engines[engine])
+	}
+	if pkg.PackageManager != "" {
+		runtimes = append(runtimes, pkg.PackageManager)
+	}
+	manifest.Runtime = strings.Join(runtimes, ", ")
+
+	manifest.Dependencies = jsonStringEntries(pkg.Dependencies, " ", "")
+	manifest.Dependencies = append(manifest.Dependencies, jsonStringEntries(pkg.PeerDependencies, " ", " (peer)")...)
+	manifest.Dependencies = append(manifest.Dependencies, jsonStringEntries(pkg.OptionalDependencies, " ", " (optional)")...)
+	manifest.DevDependencies = jsonStringEntries(pkg.DevDependencies, " ", "")
+
+	for _, overrides := range []json.RawMessage{pkg.Overrides, pkg.Resolutions, pkg.Pnpm.Overrides} {
+		manifest.Overrides = append(manifest.Overrides, flattenJSONOverrides(overrides, "")...)
+	}
+
+	manifest.Scripts = jsonStringEntries(pkg.Scripts, ": ", "")
+	var bin string
+	if json.Unmarshal(pkg.Bin, &bin) == nil && bin != "" {
+		manifest.Scripts = append(manifest.Scripts, fmt.Sprintf("bin %s: %s", pkg.Name, bin))
+	} else {
+		for _, entry := range jsonStringEntries(pkg.Bin, ": ", "") {
+			manifest.Scripts = append(manifest.Scripts, "bin "+entry)
+		}
+	}
+	if pkg.Main != "" {
+		manifest.Scripts = append(manifest.Scripts, "main: "+pkg.Main)
+	}
+	if pkg.Module != "" {
+		manifest.Scripts = append(manifest.Scripts, "module: "+pkg.Module)
+	}
+
+	summary.Manifest = manifest
+	return summary
+}
+
+// parseCargoToml summarizes a Rust Cargo.toml
+func (s *Summarizer) parseCargoToml(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var cargo map[string]interface{}
+	if _, err := toml.Decode(string(content), &cargo); err != nil {
+		summary.Error = fmt.Sprintf("Invalid TOML: %s", strings.TrimPrefix(err.Error(), "toml: "))
+		return summary
+	}
+
+	manifest := &ManifestSummary{Ecosystem: "Cargo"}
+	pkg := tomlTable(cargo, "package")
+	if pkg == nil {
+		// Virtual manifests only declare a workspace
+		pkg = tomlTable(cargo, "workspace", "package")
+	}
+	manifest.Name = tomlString(pkg, "name")
+	manifest.Version = tomlString(pkg, "version")
+	if version := tomlString(pkg, "rust-version"); version != "" {
+		manifest.Runtime = "rust " + version
+	}
+	if edition := tomlString(pkg, "edition"); edition != "" {
+		manifest.Runtime = strings.TrimSpace(manifest.Runtime + " (edition " + edition + ")")
+	}
+
+	manifest.Dependencies = cargoDependencies(tomlTable(cargo, "dependencies"), "")
+	manifest.Dependencies = append(manifest.Dependencies, cargoDependencies(tomlTable(cargo, "workspace", "dependencies"), " (workspace)")...)
+	manifest.DevDependencies = cargoDependencies(tomlTable(cargo, "dev-dependencies"), "")
+	manifest.DevDependencies = append(manifest.DevDependencies, cargoDependencies(tomlTable(cargo, "build-dependencies"), " (build)")...)
+
+	// Platform-specific dependencies live under [target.'cfg(...)'.dependencies]
+	targets := tomlTable(cargo, "target")
+	for _, platform := range sortedKeys(targets) {
+		suffix := fmt.Sprintf(" (%s)", platform)
+		manifest.Dependencies = append(manifest.Dependencies, cargoDependencies(tomlTable(targets, platform, "dependencies"), suffix)...)
+		manifest.DevDependencies = append(manifest.DevDependencies, cargoDependencies(tomlTable(targets, platform, "dev-dependencies"), suffix)...)
+	}
+
+	patches := tomlTable(cargo, "patch")
+	for _, registry := range sortedKeys(patches) {
+		for _, patch := range cargoDependencies(tomlTable(patches, registry), "") {
+			manifest.Overrides = append(manifest.Overrides, fmt.Sprintf("patch %s: %s", registry, patch))
+		}
+	}
+	for _, replace := range cargoDependencies(tomlTable(cargo, "replace"), "") {
+		manifest.Overrides = append(manifest.Overrides, "replace "+replace)
+	}
+
+	if members, ok := tomlTable(cargo, "workspace")["members"].([]interface{}); ok {
+		for _, member := range members {
+			manifest.Scripts = append(manifest.Scripts, fmt.Sprintf("member: %v", member))
+		}
+	}
+	if bins, ok := cargo["bin"].([]map[string]interface{}); ok {
+		for _, bin := range bins {
+			entry := "bin " + tomlString(bin, "name")
+			if path := tomlString(bin, "path"); path != "" {
+				entry += ": " + path
+			}
+			manifest.Scripts = append(manifest.Scripts, entry)
+		}
+	}
+	if lib := tomlTable(cargo, "lib"); lib != nil {
+		manifest.Scripts = append(manifest.Scripts, strings.TrimSuffix("lib: "+tomlString(lib, "path"), ": "))
+	}
+
+	summary.Manifest = manifest
+	return summary
+}
+
+// parsePyproject summarizes a pyproject.toml, reading PEP 621 [project]
+// metadata and falling back to [tool.poetry]
+func (s *Summarizer) parsePyproject(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var pyproject map[string]interface{}
+	if _, err := toml.Decode(string(content), &pyproject); err != nil {
+		summary.Error = fmt.Sprintf("Invalid TOML: %s", strings.TrimPrefix(err.Error(), "toml: "))
+		return summary
+	}
+
+	manifest := &ManifestSummary{Ecosystem: "Python"}
+	project := tomlTable(pyproject, "project")
+	poetry := tomlTable(pyproject, "tool", "poetry")
+
+	manifest.Name = firstNonEmpty(tomlString(project, "name"), tomlString(poetry, "name"))
+	manifest.Version = firstNonEmpty(tomlString(project, "version"), tomlString(poetry, "version"))
+	if python := tomlString(project, "requires-python"); python != "" {
+		manifest.Runtime = "python " + python
+	}
+
+	// PEP 621 dependencies are PEP 508 requirement strings
+	manifest.Dependencies = pythonRequirements(project["dependencies"], "")
+	optional := tomlTable(project, "optional-dependencies")
+	for _, group := range sortedKeys(optional) {
+		manifest.DevDependencies = append(manifest.DevDependencies, pythonRequirements(optional[group], fmt.Sprintf(" (%s)", group))...)
+	}
+	groups := tomlTable(pyproject, "dependency-groups")
+	for _, group := range sortedKeys(groups) {
+		manifest.DevDependencies = append(manifest.DevDependencies, pythonRequirements(groups[group], fmt.Sprintf(" (%s)", group))...)
+	}
+
+	// Poetry dependencies are tables of name = constraint
+	poetryDependencies := tomlTable(poetry, "dependencies")
+	if python := tomlString(poetryDependencies, "python"); python != "" && manifest.Runtime == "" {
+		manifest.Runtime = "python " + python
+	}
+	delete(poetryDependencies, "python")
+	manifest.Dependencies = append(manifest.Dependencies, cargoDependencies(poetryDependencies, "")...)
+	manifest.DevDependencies = append(manifest.DevDependencies, cargoDependencies(tomlTable(poetry, "dev-dependencies"), "")...)
+	poetryGroups := tomlTable(poetry, "group")
+	for _, group := range sortedKeys(poetryGroups) {
+		suffix := fmt.Sprintf(" (%s)", group)
+		manifest.DevDependencies = append(manifest.DevDependencies, cargoDependencies(tomlTable(poetryGroups, group, "dependencies"), suffix)...)
+	}
+
+	manifest.DevDependencies = append(manifest.DevDependencies, pythonRequirements(tomlTable(pyproject, "build-system")["requires"], " (build)")...)
+
+	uv := tomlTable(pyproject, "tool", "uv")
+	for _, override := range pythonRequirements(uv["override-dependencies"], "") {
+		manifest.Overrides = append(manifest.Overrides, "override "+override)
+	}
+	for _, source := range cargoDependencies(tomlTable(uv, "sources"), "") {
+		manifest.Overrides = append(manifest.Overrides, "source "+source)
+	}
+
+	for _, section := range []map[string]interface{}{tomlTable(project, "scripts"), tomlTable(project, "gui-scripts"), tomlTable(poetry, "scripts")} {
+		for _, name := range sortedKeys(section) {
+			manifest.Scripts = append(manifest.Scripts, fmt.Sprintf("%s: %v", name, section[name]))
+		}
+	}
+	if backend := tomlString(tomlTable(pyproject, "build-system"), "build-backend"); backend != "" {
+		manifest.Scripts = append(manifest.Scripts, "build-backend: "+backend)
+	}
+
+	summary.Manifest = manifest
+	return summary
+}
+
+// jsonStringEntries lists the members of a JSON object in file order as key,
+// separator and value, followed by suffix
+func jsonStringEntries(raw json.RawMessage, separator, suffix string) []string {
+	var values map[string]interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &values) != nil {
+		return nil
+	}
+	var entries []string
+	for _, key := range jsonObjectKeys(raw) {
+		entries = append(entries, fmt.Sprintf("%s%s%v%s", key, separator, values[key], suffix))
+	}
+	return entries
+}
+
+// flattenJSONOverrides lists npm overrides and yarn resolutions, joining
+// nested override scopes with " > "
+func flattenJSONOverrides(raw json.RawMessage, prefix string) []string {
+	var values map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &values) != nil {
+		return nil
+	}
+	var overrides []string
+	for _, key := range jsonObjectKeys(raw) {
+		var version string
+		if json.Unmarshal(values[key], &version) == nil {
+			if key == "." {
+				overrides = append(overrides, fmt.Sprintf("%s %s", strings.TrimSuffix(prefix, " > "), version))
+			} else {
+				overrides = append(overrides, fmt.Sprintf("%s%s %s", prefix, key, version))
+			}
+			continue
+		}
+		overrides = append(overrides, flattenJSONOverrides(values[key], prefix+key+" > ")...)
+	}
+	return overrides
+}
+
+// jsonObjectKeys returns the keys of a JSON object in the order they appear
+func jsonObjectKeys(raw json.RawMessage) []string {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		key, _ := token.(string)
+		keys = append(keys, key)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			break
+		}
+	}
+	return keys
+}
+
+// cargoDependencies lists a TOML dependency table, where each value is either
+// a version string or a table with version, git, path or workspace keys
+func cargoDependencies(table map[string]interface{}, suffix string) []string {
+	var dependencies []string
+	for _, name := range sortedKeys(table) {
+		entry := name
+		switch spec := table[name].(type) {
+		case string:
+			entry += " " + spec
+		case map[string]interface{}:
+			if pkg := tomlString(spec, "package"); pkg != "" {
+				entry += fmt.Sprintf(" (package %s)", pkg)
+			}
+			switch {
+			case tomlString(spec, "git") != "":
+				entry += " git " + tomlString(spec, "git")
+				for _, ref := range []string{"branch", "tag", "rev"} {
+					if value := tomlString(spec, ref); value != "" {
+						entry += fmt.Sprintf(" (%s %s)", ref, value)
+					}
+				}
+			case tomlString(spec, "path") != "":
+				entry += " path " + tomlString(spec, "path")
+			case tomlString(spec, "url") != "":
+				entry += " url " + tomlString(spec, "url")
+			case spec["workspace"] == true:
+				entry += " (workspace)"
+			}
+			if version := tomlString(spec, "version"); version != "" {
+				entry += " " + version
+			}
+			if spec["optional"] == true {
+				entry += " (optional)"
+			}
+		}
+		dependencies = append(dependencies, entry+suffix)
+	}
+	return dependencies
+}
+
+// pythonRequirements formats a list of PEP 508 requirement strings as
+// "name specifier", keeping extras and environment markers
+func pythonRequirements(value interface{}, suffix string) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var requirements []string
+	for _, item := range items {
+		requirement, ok := item.(string)
+		if !ok {
+			continue // Group includes such as {include-group = "test"}
+		}
+		matches := pep508Pattern.FindStringSubmatch(requirement)
+		if matches == nil {
+			requirements = append(requirements, requirement+suffix)
+			continue
+		}
+		entry := matches[1] + matches[2]
+		if specifier := strings.TrimSpace(matches[3]); strings.HasPrefix(specifier, ";") {
+			entry += specifier
+		} else if specifier != "" {
+			entry += " " + specifier
+		}
+		requirements = append(requirements, entry+suffix)
+	}
+	return requirements
+}
+
+// tomlTable follows a path of keys through nested TOML tables, returning nil
+// when any of them is missing
+func tomlTable(table map[string]interface{}, path ...string) map[string]interface{} {
+	for _, key := range path {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		table = next
+	}
+	return table
+}
+
+// tomlString returns a string value from a TOML table, or ""
+func tomlString(table map[string]interface{}, key string) string {
+	value, _ := table[key].(string)
+	return value
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
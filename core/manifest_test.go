@@ -0,0 +1,67 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGoMod(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		manifest ManifestSummary
+	}{
+		{
+			name: "all directives",
+			content: `module example.com/x
+
+go 1.22
+
+toolchain go1.23.1
+
+require (
+	example.com/a v1.0.0
+	example.com/b v1.10.0 // indirect
+)
+
+replace example.com/a => ../a
+
+exclude example.com/b v1.2.0
+
+tool example.com/a/cmd/gen
+`,
+			manifest: ManifestSummary{
+				Ecosystem:    "Go",
+				Name:         "example.com/x",
+				Runtime:      "go 1.22 (toolchain go1.23.1)",
+				Dependencies: []string{"example.com/a v1.0.0"},
+				Indirect:     1,
+				Overrides:    []string{"example.com/a => ../a", "exclude example.com/b v1.2.0"},
+				Scripts:      []string{"tool example.com/a/cmd/gen"},
+			},
+		},
+		{
+			name:    "versioned replace",
+			content: "module m\n\ngo 1.21\n\nrequire example.com/a v1.0.0\n\nreplace example.com/a v1.0.0 => example.com/fork v1.0.1\n",
+			manifest: ManifestSummary{
+				Ecosystem:    "Go",
+				Name:         "m",
+				Runtime:      "go 1.21",
+				Dependencies: []string{"example.com/a v1.0.0"},
+				Overrides:    []string{"example.com/a v1.0.0 => example.com/fork v1.0.1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizeContent(t, "go.mod", tt.content)
+			if summary.Manifest == nil {
+				t.Fatalf("no manifest, Error = %q", summary.Error)
+			}
+			if !reflect.DeepEqual(*summary.Manifest, tt.manifest) {
+				t.Errorf("Manifest = %+v, want %+v", *summary.Manifest, tt.manifest)
+			}
+		})
+	}
+}
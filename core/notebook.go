@@ -0,0 +1,294 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// NotebookSummary describes the kernel and cells of a Jupyter notebook
+type NotebookSummary struct {
+	Kernel        string         // Kernel display name and spec name
+	Language      string         // Language and version from language_info
+	Format        string         // nbformat version, e.g. "4.5"
+	CodeCells     int            // Code cells
+	MarkdownCells int            // Markdown cells
+	RawCells      int            // Raw cells
+	Executed      int            // Code cells with an execution count
+	Outputs       []string       // Cells with outputs and the kinds of output
+	Errors        []string       // Cells whose outputs include an error
+	Cells         []NotebookCell // Cell-by-cell preview
+}
+
+// NotebookCell is the preview of one cell
+type NotebookCell struct {
+	Index          int      // Position in the notebook, from 1
+	Kind           string   // "code", "markdown" or "raw"
+	ExecutionCount int      // 0 when never executed
+	Source         []string // First lines of the cell source
+	MoreLines      int      // Source lines not included
+	Outputs        []string // One line per output, e.g. "stream: Loaded 300 rows"
+}
+
+// notebookFile is the JSON layout of an .ipynb file. Version 3 notebooks
+// keep their cells in worksheets and code in "input" rather than "source".
+type notebookFile struct {
+	Cells      []notebookCell `json:"cells"`
+	Worksheets []struct {
+		Cells []notebookCell `json:"cells"`
+	} `json:"worksheets"`
+	Metadata struct {
+		Kernelspec struct {
+			Name        string `json:"name"`
+			DisplayName string `json:"display_name"`
+			Language    string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+	NBFormat      int `json:"nbformat"`
+	NBFormatMinor int `json:"nbformat_minor"`
+}
+
+type notebookCell struct {
+	CellType       string           `json:"cell_type"`
+	Source         json.RawMessage  `json:"source"`
+	Input          json.RawMessage  `json:"input"`
+	ExecutionCount *int             `json:"execution_count"`
+	PromptNumber   *int             `json:"prompt_number"`
+	Outputs        []notebookOutput `json:"outputs"`
+}
+
+type notebookOutput struct {
+	OutputType string                     `json:"output_type"`
+	Name       string                     `json:"name"`
+	Text       json.RawMessage            `json:"text"`
+	Data       map[string]json.RawMessage `json:"data"`
+	Ename      string                     `json:"ename"`
+	Evalue     string                     `json:"evalue"`
+}
+
+// Limits for the cell preview
+const (
+	maxNotebookPreviewCells = 30
+	maxNotebookCellLines    = 6
+)
+
+// Patterns for code cells of Python, R and Julia kernels
+var (
+	notebookImportPattern   = regexp.MustCompile(`^(?:from\s+(\S+)\s+)?import\s+(.+)|^(?:library|require)\((\w+)\)|^using\s+(.+)`)
+	notebookFunctionPattern = regexp.MustCompile(`^(?:async\s+)?def\s+(\w+)|^(\w+)\s*<-\s*function\b|^function\s+(\w+)`)
+	notebookClassPattern    = regexp.MustCompile(`^class\s+(\w+)`)
+	notebookHeaderPattern   = regexp.MustCompile(`^(#{1,6})\s+(.+)`)
+)
+
+// parseNotebook summarizes a Jupyter notebook: its kernel, cell counts,
+// imports and functions of code cells, the markdown heading outline, cells
+// with outputs or errors, and a cell-by-cell preview
+func (s *Summarizer) parseNotebook(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var file notebookFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		summary.Error = fmt.Sprintf("Invalid notebook JSON: %v", err)
+		return summary
+	}
+	cells := file.Cells
+	for _, worksheet := range file.Worksheets {
+		cells = append(cells, worksheet.Cells...)
+	}
+
+	kernelspec := file.Metadata.Kernelspec
+	notebook := &NotebookSummary{
+		Kernel:   kernelspec.DisplayName,
+		Language: strings.TrimSpace(firstNonEmpty(file.Metadata.LanguageInfo.Name, kernelspec.Language) + " " + file.Metadata.LanguageInfo.Version),
+		Format:   fmt.Sprintf("%d.%d", file.NBFormat, file.NBFormatMinor),
+	}
+	if kernelspec.Name != "" && kernelspec.Name != kernelspec.DisplayName {
+		notebook.Kernel = strings.TrimSpace(notebook.Kernel + " (" + kernelspec.Name + ")")
+	}
+
+	for i, cell := range cells {
+		source := notebookText(cell.Source)
+		if cell.Source == nil {
+			source = notebookText(cell.Input)
+		}
+		lines := strings.Split(strings.TrimRight(source, "\n"), "\n")
+		preview := NotebookCell{Index: i + 1, Kind: cell.CellType}
+
+		switch cell.CellType {
+		case "code":
+			notebook.CodeCells++
+			if count := cell.ExecutionCount; count != nil || cell.PromptNumber != nil {
+				if count == nil {
+					count = cell.PromptNumber
+				}
+				notebook.Executed++
+				preview.ExecutionCount = *count
+			}
+			notebookCode(lines, &summary)
+			notebookOutputs(cell, preview.Index, notebook, &preview)
+		case "markdown", "heading":
+			notebook.MarkdownCells++
+			preview.Kind = "markdown"
+			inFence := false
+			for _, line := range lines {
+				line = strings.TrimSpace(line)
+				if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+					inFence = !inFence
+				}
+				if matches := notebookHeaderPattern.FindStringSubmatch(line); matches != nil && !inFence {
+					summary.Headers = append(summary.Headers, matches[1]+" "+matches[2])
+				}
+			}
+		default:
+			notebook.RawCells++
+		}
+
+		if len(notebook.Cells) < maxNotebookPreviewCells {
+			if len(lines) > maxNotebookCellLines {
+				preview.MoreLines = len(lines) - maxNotebookCellLines
+				lines = lines[:maxNotebookCellLines]
+			}
+			preview.Source = lines
+			notebook.Cells = append(notebook.Cells, preview)
+		}
+	}
+
+	summary.Imports = uniqueStrings(summary.Imports)
+	summary.Notebook = notebook
+	return summary
+}
+
+// notebookCode collects imports, functions, classes and shell or magic
+// commands from the lines of a code cell
+func notebookCode(lines []string, summary *FileSummary) {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "%") || strings.HasPrefix(trimmed, "!") {
+			summary.Commands = append(summary.Commands, trimmed)
+			continue
+		}
+		// Only top-level definitions, as in the Python parser
+		if trimmed != line {
+			continue
+		}
+		if matches := notebookImportPattern.FindStringSubmatch(line); matches != nil {
+			switch {
+			case matches[1] != "":
+				for _, name := range notebookImportNames(matches[2]) {
+					summary.Imports = append(summary.Imports, matches[1]+"."+name)
+				}
+			case matches[3] != "":
+				summary.Imports = append(summary.Imports, matches[3])
+			default:
+				summary.Imports = append(summary.Imports, notebookImportNames(firstNonEmpty(matches[2], matches[4]))...)
+			}
+		}
+		if matches := notebookFunctionPattern.FindStringSubmatch(line); matches != nil {
+			summary.Functions = append(summary.Functions, firstNonEmpty(matches[1], matches[2], matches[3]))
+			summary.FunctionCount++
+		}
+		if matches := notebookClassPattern.FindStringSubmatch(line); matches != nil {
+			summary.Types = append(summary.Types, matches[1])
+		}
+	}
+}
+
+// notebookOutputs records the outputs of a code cell in the summary and
+// describes each one in the cell preview
+func notebookOutputs(cell notebookCell, index int, notebook *NotebookSummary, preview *NotebookCell) {
+	if len(cell.Outputs) == 0 {
+		return
+	}
+	var kinds []string
+	for _, output := range cell.Outputs {
+		switch output.OutputType {
+		case "error", "pyerr":
+			evalue := notebookPlainText(output.Evalue)
+			notebook.Errors = append(notebook.Errors, fmt.Sprintf("cell %d: %s: %s", index, output.Ename, evalue))
+			preview.Outputs = append(preview.Outputs, fmt.Sprintf("✗ %s: %s", output.Ename, evalue))
+			kinds = append(kinds, "error")
+		case "stream":
+			text := notebookText(output.Text)
+			preview.Outputs = append(preview.Outputs, fmt.Sprintf("%s: %s", firstNonEmpty(output.Name, "stream"), notebookFirstLine(text)))
+			kinds = append(kinds, firstNonEmpty(output.Name, "stream"))
+		default:
+			// execute_result and display_data carry one or more MIME bundles
+			mimeTypes := sortedKeys(output.Data)
+			description := strings.Join(mimeTypes, ", ")
+			if text, exists := output.Data["text/plain"]; exists {
+				description = notebookFirstLine(notebookText(text))
+				if len(mimeTypes) > 1 {
+					description += " (" + strings.Join(mimeTypes, ", ") + ")"
+				}
+			}
+			preview.Outputs = append(preview.Outputs, fmt.Sprintf("%s: %s", output.OutputType, description))
+			kinds = append(kinds, output.OutputType)
+		}
+	}
+	notebook.Outputs = append(notebook.Outputs, fmt.Sprintf("cell %d: %s", index, strings.Join(uniqueStrings(kinds), ", ")))
+}
+
+// notebookText joins a notebook text field, which is either a string or a
+// list of lines
+func notebookText(raw json.RawMessage) string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+	var lines []string
+	if json.Unmarshal(raw, &lines) == nil {
+		return strings.Join(lines, "")
+	}
+	return ""
+}
+
+// notebookImportNames splits the names of an import or using statement,
+// dropping "as" aliases, e.g. "numpy as np, os" gives numpy and os
+func notebookImportNames(list string) []string {
+	var names []string
+	for _, part := range strings.Split(list, ",") {
+		if fields := strings.Fields(strings.Trim(part, " ()\\")); len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
+
+// notebookPlainText removes terminal escape sequences from output text and
+// keeps only what a terminal would finally show of lines redrawn with \r,
+// such as progress bars
+func notebookPlainText(text string) string {
+	lines := strings.Split(ansi.Strip(text), "\n")
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if cut := strings.LastIndexByte(line, '\r'); cut >= 0 {
+			line = line[cut+1:]
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// notebookFirstLine returns the first non-empty line of output text, noting
+// how many lines follow
+func notebookFirstLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(notebookPlainText(text)), "\n")
+	first := strings.TrimSpace(lines[0])
+	if len(lines) > 1 {
+		first += fmt.Sprintf(" (+%s)", pluralize(len(lines)-1, "line"))
+	}
+	return first
+}
@@ -0,0 +1,45 @@
+package core
+
+import "testing"
+
+func TestNotebookPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "done", "done"},
+		{"ansi colors", "\x1b[31mred\x1b[0m", "red"},
+		{"progress bar", "\x1b[31mred\x1b[0m 10%\r 50%\r100%", "100%"},
+		{"trailing carriage return", "a\r\nb\r\n", "a\nb\n"},
+		{"redrawn lines", "1/3\r2/3\r3/3\nok", "3/3\nok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notebookPlainText(tt.text); got != tt.want {
+				t.Errorf("notebookPlainText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotebookCodeImports(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{"aliases", "import numpy as np, os", []string{"numpy", "os"}},
+		{"from list", "from a.b import (c as d, e)", []string{"a.b.c", "a.b.e"}},
+		{"indented", "    import os", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var summary FileSummary
+			notebookCode([]string{tt.line}, &summary)
+			assertStrings(t, "Imports", summary.Imports, tt.want)
+		})
+	}
+}
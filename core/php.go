@@ -0,0 +1,138 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// PHP declaration patterns, applied to comment-stripped lines
+var (
+	phpNamespacePattern = regexp.MustCompile(`^namespace\s+([\w\\]+)\s*[;{]`)
+	phpUsePattern       = regexp.MustCompile(`^use\s+(function\s+|const\s+)?([\w\\]+(?:\s*\{[^}]*\})?)(?:\s+as\s+(\w+))?\s*;`)
+	phpTraitUsePattern  = regexp.MustCompile(`^use\s+([\w\\,\s]+)\s*[;{]`)
+	phpAttributePattern = regexp.MustCompile(`^#\[([\w\\]+)[^\]]*\]\s*`)
+	phpTypePattern      = regexp.MustCompile(`^((?:(?:abstract|final|readonly)\s+)*)(class|interface|trait|enum)\s+(\w+)`)
+	phpFunctionPattern  = regexp.MustCompile(`^((?:(?:public|private|protected|static|abstract|final)\s+)*)function\s+&?(\w+)\s*\(`)
+)
+
+// parsePHPFile extracts the namespace, use imports, classes, interfaces,
+// traits and functions from PHP source
+func (s *Summarizer) parsePHPFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(stripComments(string(content), true, true), "\n")
+	summary.LineCount = len(lines)
+
+	var scopes scopeStack
+	var namespaces []string
+	attributeCounts := make(map[string]int)
+	var attributeOrder []string
+	depth := 0
+	typeIndexes := make(map[string]int)    // Position of each type in summary.Types
+	traitUses := make(map[string][]string) // Traits composed into each type
+
+	// addMember records a trait use or method declared directly in a type
+	// body, or a function outside of any type
+	addMember := func(line string, lineDepth int) {
+		owner, inType := scopes.current()
+		if inType && lineDepth == owner.depth+1 {
+			if matches := phpTraitUsePattern.FindStringSubmatch(line); matches != nil {
+				for _, trait := range strings.Split(matches[1], ",") {
+					traitUses[owner.name] = append(traitUses[owner.name], strings.TrimSpace(trait))
+				}
+				return
+			}
+		}
+
+		if matches := phpFunctionPattern.FindStringSubmatch(line); matches != nil {
+			if inType && owner.opened && lineDepth == owner.depth+1 {
+				// Class members default to public visibility
+				summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], "+ ")+matches[2])
+				summary.FunctionCount++
+			} else if !inType {
+				summary.Functions = append(summary.Functions, matches[2])
+				summary.FunctionCount++
+			}
+		}
+	}
+
+	for _, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		lineDepth := depth
+		depth += braceDelta(line)
+
+		if line == "" {
+			continue
+		}
+
+		if matches := phpNamespacePattern.FindStringSubmatch(line); matches != nil {
+			namespaces = append(namespaces, matches[1])
+			continue
+		}
+
+		if _, inType := scopes.current(); !inType {
+			if matches := phpUsePattern.FindStringSubmatch(line); matches != nil {
+				use := normalizeSpace(matches[1] + matches[2])
+				if matches[3] != "" {
+					use += " as " + matches[3]
+				}
+				summary.Imports = append(summary.Imports, use)
+				continue
+			}
+		}
+
+		for {
+			matches := phpAttributePattern.FindStringSubmatch(line)
+			if matches == nil {
+				break
+			}
+			name := "#[" + matches[1] + "]"
+			if attributeCounts[name] == 0 {
+				attributeOrder = append(attributeOrder, name)
+			}
+			attributeCounts[name]++
+			line = line[len(matches[0]):]
+		}
+
+		if matches := phpTypePattern.FindStringSubmatch(line); matches != nil {
+			kind := matches[2]
+			name := matches[3]
+			typeIndexes[name] = len(summary.Types)
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", name, kind))
+			switch kind {
+			case "class":
+				summary.Structs = append(summary.Structs, name)
+			case "interface":
+				summary.Interfaces = append(summary.Interfaces, name)
+			}
+
+			scopes = scopes.push(name, kind, lineDepth, strings.Contains(line, "{"))
+			// Members of a body written on the declaration line
+			for _, member := range bodyMembers(line) {
+				addMember(member, lineDepth+1)
+			}
+			scopes = scopes.update(depth)
+			continue
+		}
+
+		addMember(line, lineDepth)
+		scopes = scopes.update(depth)
+	}
+
+	// Traits a type uses are shown with the type, e.g. "User (class, uses HasName)"
+	for name, traits := range traitUses {
+		if index, exists := typeIndexes[name]; exists {
+			summary.Types[index] = strings.TrimSuffix(summary.Types[index], ")") + ", uses " + strings.Join(traits, ", ") + ")"
+		}
+	}
+
+	summary.Package = strings.Join(namespaces, ", ")
+	summary.Annotations = formatCounts(attributeOrder, attributeCounts)
+	return summary
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// parseProperties extracts keys from Java .properties files, joining
+// continuation lines and decoding escapes such as \u00e9
+func (s *Summarizer) parseProperties(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	summary.LineCount = len(lines)
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+
+		// Skip comments and empty lines
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// A line ending in an odd number of backslashes continues on the next
+		// line, whose leading whitespace is ignored
+		for continuesLine(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		if key := propertyKey(line); key != "" {
+			summary.ConfigKeys = append(summary.ConfigKeys, key)
+		}
+	}
+
+	return summary
+}
+
+// continuesLine reports whether a line ends with an unescaped backslash
+func continuesLine(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// propertyKey returns the decoded key of a logical line, which ends at the
+// first unescaped '=', ':' or whitespace
+func propertyKey(line string) string {
+	var key strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f':
+			return key.String()
+		case c == '\\' && i+1 < len(line):
+			i++
+			switch line[i] {
+			case 't':
+				key.WriteByte('\t')
+			case 'n':
+				key.WriteByte('\n')
+			case 'r':
+				key.WriteByte('\r')
+			case 'f':
+				key.WriteByte('\f')
+			case 'u':
+				if i+4 < len(line) {
+					if code, err := strconv.ParseUint(line[i+1:i+5], 16, 32); err == nil {
+						key.WriteRune(rune(code))
+						i += 4
+						continue
+					}
+				}
+				key.WriteByte('u')
+			default:
+				key.WriteByte(line[i])
+			}
+		default:
+			key.WriteByte(c)
+		}
+	}
+	return key.String()
+}
@@ -0,0 +1,386 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ProtoSummary describes the definitions of a Protocol Buffers file
+type ProtoSummary struct {
+	Syntax   string       // "proto3", "proto2" or "edition 2023"
+	Options  []string     // File options, e.g. go_package = "example.com/pb"
+	Messages []Definition // Messages with their fields, nested ones qualified
+	Enums    []Definition // Enums with their values
+	Services []Definition // Services with their RPC signatures
+}
+
+// Definition is a named schema definition, such as a message, enum or
+// service, with its fields, values or methods
+type Definition struct {
+	Name    string
+	Entries []string
+}
+
+// tokenStream is a cursor over the tokens of a schema file
+type tokenStream struct {
+	tokens []string
+	pos    int
+}
+
+// protoParser walks the tokens of a .proto file
+type protoParser struct {
+	tokenStream
+	proto *ProtoSummary
+}
+
+// parseProto extracts the syntax, package, options, imports, messages, enums
+// and services of a Protocol Buffers file
+func (s *Summarizer) parseProto(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	p := &protoParser{tokenStream: tokenStream{tokens: tokenizeProto(string(content))}, proto: &ProtoSummary{Syntax: "proto2"}}
+	for !p.done() {
+		switch token := p.next(); token {
+		case "syntax", "edition":
+			p.expect("=")
+			value := strings.Trim(p.next(), `"'`)
+			if token == "edition" {
+				value = "edition " + value
+			}
+			p.proto.Syntax = value
+			p.skipStatement()
+		case "package":
+			summary.Package = p.next()
+			p.skipStatement()
+		case "import":
+			path := p.next()
+			if path == "public" || path == "weak" {
+				path = strings.Trim(p.next(), `"'`) + " (" + path + ")"
+			}
+			summary.Imports = append(summary.Imports, strings.Trim(path, `"'`))
+			p.skipStatement()
+		case "option":
+			p.proto.Options = append(p.proto.Options, p.option())
+		case "message":
+			p.message(p.next(), "")
+		case "enum":
+			p.enum(p.next(), "")
+		case "service":
+			p.service(p.next())
+		case "extend":
+			p.message(p.next(), "extend ")
+		case ";":
+		default:
+			p.skipStatement()
+		}
+	}
+
+	summary.Proto = p.proto
+	return summary
+}
+
+// tokenizeProto splits .proto source into identifiers, numbers, strings and
+// punctuation, dropping comments
+func tokenizeProto(content string) []string {
+	var tokens []string
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				return tokens
+			}
+			i += end
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(content) && content[end] != c {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(content))
+			tokens = append(tokens, content[i:end])
+			i = end
+		case c == '_' || c == '.' || c == '-' || c == '+' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			// Identifiers may be dotted, as in google.protobuf.Timestamp
+			end := i + 1
+			for end < len(content) {
+				d := content[end]
+				if !(d == '_' || d == '.' || d >= '0' && d <= '9' || d >= 'a' && d <= 'z' || d >= 'A' && d <= 'Z') {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, content[i:end])
+			i = end
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+// done reports whether all tokens have been consumed
+func (t *tokenStream) done() bool {
+	return t.pos >= len(t.tokens)
+}
+
+// next consumes and returns the next token, or "" at the end
+func (t *tokenStream) next() string {
+	if t.done() {
+		return ""
+	}
+	t.pos++
+	return t.tokens[t.pos-1]
+}
+
+// peek returns the next token without consuming it
+func (t *tokenStream) peek() string {
+	if t.done() {
+		return ""
+	}
+	return t.tokens[t.pos]
+}
+
+// expect consumes the next token if it matches
+func (t *tokenStream) expect(token string) bool {
+	if t.peek() == token {
+		t.pos++
+		return true
+	}
+	return false
+}
+
+// skipStatement consumes tokens up to and including the next ";", skipping
+// over any nested block
+func (p *protoParser) skipStatement() {
+	for !p.done() {
+		switch p.next() {
+		case ";":
+			return
+		case "{":
+			p.skipBlock()
+			return
+		}
+	}
+}
+
+// skipBlock consumes tokens up to the "}" closing an already opened block
+func (p *protoParser) skipBlock() {
+	for depth := 1; depth > 0 && !p.done(); {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+}
+
+// option reads "name = value;" after the option keyword, including
+// parenthesized custom option names and aggregate values
+func (p *protoParser) option() string {
+	var name strings.Builder
+	for !p.done() && p.peek() != "=" && p.peek() != ";" {
+		name.WriteString(p.next())
+	}
+	p.expect("=")
+	value := p.next()
+	if value == "{" {
+		p.skipBlock()
+		value = "{...}"
+	}
+	p.skipStatement()
+	return name.String() + " = " + value
+}
+
+// bracketed consumes a [...] field option list if present
+func (p *protoParser) bracketed() {
+	if !p.expect("[") {
+		return
+	}
+	for depth := 1; depth > 0 && !p.done(); {
+		switch p.next() {
+		case "[":
+			depth++
+		case "]":
+			depth--
+		}
+	}
+}
+
+// message reads a message or extend body, recording nested messages and
+// enums under qualified names
+func (p *protoParser) message(name, prefix string) {
+	if !p.expect("{") {
+		return
+	}
+	index := len(p.proto.Messages)
+	p.proto.Messages = append(p.proto.Messages, Definition{Name: prefix + name})
+
+	var entries []string
+	oneof := ""
+	depth := 0 // Depth of open oneof blocks
+	for !p.done() {
+		token := p.next()
+		switch token {
+		case "}":
+			if depth > 0 {
+				depth--
+				oneof = ""
+				continue
+			}
+			p.proto.Messages[index].Entries = entries
+			return
+		case ";":
+			continue
+		case "message":
+			p.message(name+"."+p.next(), "")
+			continue
+		case "enum":
+			p.enum(p.next(), name+".")
+			continue
+		case "extend":
+			p.message(p.next(), "extend ")
+			continue
+		case "option", "reserved", "extensions":
+			p.skipStatement()
+			continue
+		case "oneof":
+			oneof = p.next()
+			if p.expect("{") {
+				depth++
+			}
+			continue
+		}
+
+		// A field: [label] type name = number [options];
+		label := ""
+		fieldType := token
+		switch token {
+		case "repeated", "optional", "required":
+			label = token + " "
+			fieldType = p.next()
+		}
+		if fieldType == "map" && p.expect("<") {
+			var key strings.Builder
+			for !p.done() && p.peek() != ">" {
+				key.WriteString(p.next())
+			}
+			p.expect(">")
+			fieldType = "map<" + strings.ReplaceAll(key.String(), ",", ", ") + ">"
+		}
+		fieldName := p.next()
+		if fieldType == "group" {
+			// Proto2 groups declare a nested message inline
+			fieldType, fieldName = fieldName, strings.ToLower(fieldName)
+		}
+		number := ""
+		if p.expect("=") {
+			number = p.next()
+		}
+		p.bracketed()
+		if p.peek() == "{" {
+			p.next()
+			p.skipBlock()
+		} else {
+			p.expect(";")
+		}
+
+		entry := fmt.Sprintf("%s: %s%s %s", number, label, fieldType, fieldName)
+		if oneof != "" {
+			entry += " (oneof " + oneof + ")"
+		}
+		entries = append(entries, entry)
+	}
+	p.proto.Messages[index].Entries = entries
+}
+
+// enum reads an enum body and records its values with their numbers
+func (p *protoParser) enum(name, prefix string) {
+	if !p.expect("{") {
+		return
+	}
+	definition := Definition{Name: prefix + name}
+	for !p.done() {
+		token := p.next()
+		switch token {
+		case "}":
+			p.proto.Enums = append(p.proto.Enums, definition)
+			return
+		case ";":
+		case "option", "reserved":
+			p.skipStatement()
+		default:
+			value := token
+			if p.expect("=") {
+				value += " = " + p.next()
+			}
+			p.bracketed()
+			p.expect(";")
+			definition.Entries = append(definition.Entries, value)
+		}
+	}
+	p.proto.Enums = append(p.proto.Enums, definition)
+}
+
+// service reads a service body and records each RPC as
+// "Name(stream Request) → stream Response"
+func (p *protoParser) service(name string) {
+	if !p.expect("{") {
+		return
+	}
+	definition := Definition{Name: name}
+	for !p.done() {
+		switch p.next() {
+		case "}":
+			p.proto.Services = append(p.proto.Services, definition)
+			return
+		case "rpc":
+			method := p.next()
+			request := p.rpcType()
+			p.expect("returns")
+			response := p.rpcType()
+			if p.expect("{") {
+				p.skipBlock()
+			} else {
+				p.expect(";")
+			}
+			definition.Entries = append(definition.Entries, fmt.Sprintf("%s(%s) → %s", method, request, response))
+		case ";":
+		default:
+			p.skipStatement()
+		}
+	}
+	p.proto.Services = append(p.proto.Services, definition)
+}
+
+// rpcType reads a parenthesized RPC message type with its stream marker
+func (p *protoParser) rpcType() string {
+	if !p.expect("(") {
+		return ""
+	}
+	var parts []string
+	for !p.done() && p.peek() != ")" {
+		parts = append(parts, p.next())
+	}
+	p.expect(")")
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,243 @@
+package core
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"parsec/utils"
+)
+
+// sniffSize is how many leading bytes of a file are passed to Sniff functions
+const sniffSize = 512
+
+// Parser produces a summary for one kind of file
+type Parser interface {
+	Parse(s *Summarizer, fullPath string, summary FileSummary) FileSummary
+}
+
+// ParserFunc adapts an ordinary function, or a Summarizer method expression
+// such as (*Summarizer).parseGoFile, to the Parser interface
+type ParserFunc func(s *Summarizer, fullPath string, summary FileSummary) FileSummary
+
+// Parse calls f(s, fullPath, summary)
+func (f ParserFunc) Parse(s *Summarizer, fullPath string, summary FileSummary) FileSummary {
+	return f(s, fullPath, summary)
+}
+
+// ParserSpec describes a parser and the files it should handle
+type ParserSpec struct {
+	Language   string                 // Language name shown in the summary
+	Extensions []string               // File extensions including the dot, e.g. ".go"
+	Filenames  []string               // Exact file names, e.g. "Makefile"
+	Sniff      func(head []byte) bool // Optional content check for files not matched by name
+	Icon       string                 // Optional file list icon for the extensions and file names
+	Parser     Parser
+}
+
+var (
+	registryMu     sync.RWMutex
+	parserSpecs    []ParserSpec
+	parsersByExt   = make(map[string]int)
+	parsersByName  = make(map[string]int)
+	parsersBySniff []int
+)
+
+func init() {
+	for _, spec := range builtinParsers() {
+		RegisterParser(spec)
+	}
+}
+
+// RegisterParser adds a parser to the registry. A later registration for the
+// same extension or file name replaces the earlier one, so built-in parsers can
+// be overridden. It is safe to call while files are being listed and summarized.
+func RegisterParser(spec ParserSpec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	index := len(parserSpecs)
+	parserSpecs = append(parserSpecs, spec)
+
+	for _, ext := range spec.Extensions {
+		ext = strings.ToLower(ext)
+		parsersByExt[ext] = index
+		utils.AddSupportedExtension(ext)
+		if spec.Icon != "" {
+			utils.RegisterFileIcon(ext, spec.Icon)
+		}
+	}
+	for _, name := range spec.Filenames {
+		parsersByName[strings.ToLower(name)] = index
+		utils.AddSupportedFilename(strings.ToLower(name))
+		if spec.Icon != "" {
+			utils.RegisterFileIcon(strings.ToLower(name), spec.Icon)
+		}
+	}
+	if spec.Sniff != nil {
+		parsersBySniff = append(parsersBySniff, index)
+	}
+}
+
+// LookupParser finds the parser registered for a file's name or extension
+func LookupParser(filePath string) (ParserSpec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	base := strings.ToLower(filepath.Base(filePath))
+	if index, exists := parsersByName[base]; exists {
+		return parserSpecs[index], true
+	}
+	if index, exists := parsersByExt[strings.ToLower(filepath.Ext(filePath))]; exists {
+		return parserSpecs[index], true
+	}
+	return ParserSpec{}, false
+}
+
+// sniffParser checks registered Sniff functions against the start of a file,
+// preferring the most recently registered parser
+func sniffParser(fullPath string) (ParserSpec, bool) {
+	registryMu.RLock()
+	candidates := make([]int, len(parsersBySniff))
+	copy(candidates, parsersBySniff)
+	registryMu.RUnlock()
+
+	if len(candidates) == 0 {
+		return ParserSpec{}, false
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return ParserSpec{}, false
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return ParserSpec{}, false
+	}
+	head = head[:n]
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for i := len(candidates) - 1; i >= 0; i-- {
+		spec := parserSpecs[candidates[i]]
+		if spec.Sniff(head) {
+			return spec, true
+		}
+	}
+	return ParserSpec{}, false
+}
+
+// findParser resolves the parser for a file by name, extension and then content
+func findParser(fullPath string) (ParserSpec, bool) {
+	if spec, found := LookupParser(fullPath); found {
+		return spec, true
+	}
+	return sniffParser(fullPath)
+}
+
+// RegexParser builds a Parser from a set of line-based regex patterns, which is
+// the quickest way to add basic support for a new language
+func RegexParser(config LanguageConfig) Parser {
+	return ParserFunc(func(s *Summarizer, fullPath string, summary FileSummary) FileSummary {
+		return s.parseSourceCode(fullPath, summary, config)
+	})
+}
+
+// builtinParsers lists the parsers that ship with parsec
+func builtinParsers() []ParserSpec {
+	text := ParserFunc((*Summarizer).parseTextFile)
+	shell := ParserFunc((*Summarizer).parseShellFile)
+
+	return []ParserSpec{
+		// Programming languages
+		{Language: "Go", Extensions: []string{".go"}, Parser: ParserFunc((*Summarizer).parseGoFile)},
+		{Language: "Python", Extensions: []string{".py"}, Sniff: shebangSniffer("python"), Parser: ParserFunc((*Summarizer).parsePythonFile)},
+		{Language: "JavaScript", Extensions: []string{".js"}, Sniff: shebangSniffer("node", "deno"), Parser: ParserFunc((*Summarizer).parseJavaScriptFile)},
+		{Language: "TypeScript", Extensions: []string{".ts"}, Parser: ParserFunc((*Summarizer).parseJavaScriptFile)},
+		{Language: "React/JSX", Extensions: []string{".jsx"}, Parser: ParserFunc((*Summarizer).parseJavaScriptFile)},
+		{Language: "React/TSX", Extensions: []string{".tsx"}, Parser: ParserFunc((*Summarizer).parseJavaScriptFile)},
+		{Language: "Rust", Extensions: []string{".rs"}, Parser: ParserFunc((*Summarizer).parseRustFile)},
+		{Language: "C++", Extensions: []string{".cpp", ".cc"}, Parser: ParserFunc((*Summarizer).parseCppFile)},
+		{Language: "Java", Extensions: []string{".java"}, Parser: ParserFunc((*Summarizer).parseJavaFile)},
+		{Language: "C", Extensions: []string{".c"}, Parser: ParserFunc((*Summarizer).parseCFile)},
+		{Language: "C Header", Extensions: []string{".h"}, Parser: ParserFunc((*Summarizer).parseCFile)},
+		{Language: "C++ Header", Extensions: []string{".hpp"}, Parser: text},
+		{Language: "C#", Extensions: []string{".cs"}, Parser: ParserFunc((*Summarizer).parseCSharpFile)},
+		{Language: "PHP", Extensions: []string{".php"}, Parser: ParserFunc((*Summarizer).parsePHPFile)},
+		{Language: "Ruby", Extensions: []string{".rb", ".rake"}, Filenames: []string{"Rakefile"}, Sniff: shebangSniffer("ruby"), Parser: ParserFunc((*Summarizer).parseRubyFile)},
+		{Language: "Perl", Extensions: []string{".pl", ".pm"}, Icon: "🐪", Sniff: shebangSniffer("perl"), Parser: RegexParser(perlConfig)},
+		{Language: "Lua", Extensions: []string{".lua"}, Icon: "🌙", Parser: RegexParser(luaConfig)},
+		{Language: "Swift", Extensions: []string{".swift"}, Parser: ParserFunc((*Summarizer).parseSwiftFile)},
+		{Language: "Kotlin", Extensions: []string{".kt", ".kts"}, Parser: ParserFunc((*Summarizer).parseKotlinFile)},
+		{Language: "Scala", Extensions: []string{".scala", ".sc"}, Parser: ParserFunc((*Summarizer).parseScalaFile)},
+
+		// Markup and documentation
+		{Language: "Markdown", Extensions: []string{".md", ".markdown"}, Parser: ParserFunc((*Summarizer).parseMarkdown)},
+		{Language: "Text", Extensions: []string{".txt"}, Parser: text},
+		{Language: "reStructuredText", Extensions: []string{".rst"}, Parser: text},
+		{Language: "HTML", Extensions: []string{".html", ".htm"}, Parser: ParserFunc((*Summarizer).parseHTML)},
+
+		// Stylesheets
+		{Language: "CSS", Extensions: []string{".css"}, Parser: ParserFunc((*Summarizer).parseCSS)},
+		{Language: "SCSS", Extensions: []string{".scss"}, Parser: ParserFunc((*Summarizer).parseCSS)},
+		{Language: "Less", Extensions: []string{".less"}, Parser: ParserFunc((*Summarizer).parseCSS)},
+
+		// Configuration files
+		{Language: "JSON", Extensions: []string{".json"}, Parser: ParserFunc((*Summarizer).parseJSON)},
+		{Language: "YAML", Extensions: []string{".yaml", ".yml"}, Parser: ParserFunc((*Summarizer).parseYAML)},
+		{Language: "TOML", Extensions: []string{".toml"}, Parser: ParserFunc((*Summarizer).parseTOML)},
+		{Language: "INI", Extensions: []string{".ini"}, Parser: ParserFunc((*Summarizer).parseINI)},
+		{Language: "Config", Extensions: []string{".cfg", ".conf"}, Parser: ParserFunc((*Summarizer).parseINI)},
+		{Language: "Environment", Extensions: []string{".env"}, Parser: ParserFunc((*Summarizer).parseEnv)},
+		{Language: "Properties", Extensions: []string{".properties"}, Parser: ParserFunc((*Summarizer).parseProperties)},
+
+		// Data files
+		{Language: "XML", Extensions: []string{".xml"}, Parser: ParserFunc((*Summarizer).parseXML)},
+		{Language: "SVG", Extensions: []string{".svg"}, Icon: "🖼️", Parser: ParserFunc((*Summarizer).parseXML)},
+		{Language: "MSBuild Project", Extensions: []string{".csproj", ".vbproj", ".fsproj"}, Icon: "🔷", Parser: ParserFunc((*Summarizer).parseXML)},
+		{Language: "CSV", Extensions: []string{".csv"}, Parser: ParserFunc((*Summarizer).parseCSV)},
+		{Language: "TSV", Extensions: []string{".tsv"}, Icon: "📊", Parser: ParserFunc((*Summarizer).parseCSV)},
+		{Language: "Log", Extensions: []string{".log"}, Parser: ParserFunc((*Summarizer).parseLogFile)},
+		{Language: "SQL", Extensions: []string{".sql"}, Parser: ParserFunc((*Summarizer).parseSQL)},
+		{Language: "Jupyter Notebook", Extensions: []string{".ipynb"}, Icon: "📓", Parser: ParserFunc((*Summarizer).parseNotebook)},
+
+		// Schemas and interface definitions
+		{Language: "Protocol Buffers", Extensions: []string{".proto"}, Icon: "📨", Parser: ParserFunc((*Summarizer).parseProto)},
+		{Language: "GraphQL", Extensions: []string{".graphql", ".gql", ".graphqls"}, Icon: "🕸️", Parser: ParserFunc((*Summarizer).parseGraphQL)},
+
+		// Infrastructure as code
+		{Language: "Terraform", Extensions: []string{".tf", ".tfvars"}, Icon: "🏗️", Parser: ParserFunc((*Summarizer).parseTerraform)},
+		{Language: "HCL", Extensions: []string{".hcl"}, Icon: "🏗️", Parser: ParserFunc((*Summarizer).parseTerraform)},
+
+		// Build and packaging
+		{Language: "Dockerfile", Extensions: []string{".dockerfile", ".containerfile"}, Filenames: []string{"Dockerfile", "Containerfile"}, Icon: "🐳", Sniff: isDockerfile, Parser: ParserFunc((*Summarizer).parseDockerfile)},
+
+		{Language: "Makefile", Extensions: []string{".mk", ".mak", ".makefile"}, Filenames: []string{"Makefile", "GNUmakefile"}, Icon: "🔨", Parser: ParserFunc((*Summarizer).parseMakefile)},
+		{Language: "Justfile", Extensions: []string{".just"}, Filenames: []string{"justfile", ".justfile"}, Icon: "🔨", Parser: ParserFunc((*Summarizer).parseJustfile)},
+		{Language: "Taskfile", Filenames: []string{"Taskfile.yml", "Taskfile.yaml", "Taskfile.dist.yml", "Taskfile.dist.yaml"}, Icon: "🔨", Parser: ParserFunc((*Summarizer).parseTaskfile)},
+
+		// Project manifests, matched by name before their .json/.toml/.mod extensions
+		{Language: "Go Module", Filenames: []string{"go.mod"}, Icon: "🐹", Parser: ParserFunc((*Summarizer).parseGoMod)},
+		{Language: "npm Package", Filenames: []string{"package.json"}, Icon: "📦", Parser: ParserFunc((*Summarizer).parsePackageJSON)},
+		{Language: "Cargo Manifest", Filenames: []string{"Cargo.toml"}, Icon: "🦀", Parser: ParserFunc((*Summarizer).parseCargoToml)},
+		{Language: "Python Project", Filenames: []string{"pyproject.toml"}, Icon: "🐍", Parser: ParserFunc((*Summarizer).parsePyproject)},
+		{Language: "Lockfile", Filenames: []string{"go.sum", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "Cargo.lock", "poetry.lock"}, Icon: "🔒", Parser: ParserFunc((*Summarizer).parseLockfile)},
+
+		// Shell and scripts
+		{Language: "Shell", Extensions: []string{".sh", ".ksh"}, Sniff: shebangSniffer("sh", "dash", "ash", "ksh", "bash", "zsh", "fish"), Parser: shell},
+		{Language: "Bash", Extensions: []string{".bash"}, Parser: shell},
+		{Language: "Zsh", Extensions: []string{".zsh"}, Parser: shell},
+		{Language: "Fish", Extensions: []string{".fish"}, Parser: shell},
+		{Language: "PowerShell", Extensions: []string{".ps1"}, Parser: text},
+		{Language: "Batch", Extensions: []string{".bat"}, Parser: text},
+		{Language: "Command", Extensions: []string{".cmd"}, Parser: text},
+
+		// Compiled binaries, inspected without running them
+		{Language: "Executable", Extensions: []string{".exe", ".dll", ".so", ".dylib"}, Icon: "⚙️", Sniff: isBinaryExecutable, Parser: ParserFunc((*Summarizer).parseExecutable)},
+	}
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Ruby patterns; requires are matched on raw lines, everything else on
+// comment-stripped lines
+var (
+	rubyRequirePattern    = regexp.MustCompile(`^\s*(require|require_relative|load)\s*\(?\s*['"]([^'"]+)['"]`)
+	rubyTypePattern       = regexp.MustCompile(`^(class|module)\s+([A-Z][\w:]*)`)
+	rubySingletonPattern  = regexp.MustCompile(`^class\s*<<\s*self\b`)
+	rubyDefPattern        = regexp.MustCompile(`^(?:(private|protected|public)\s+)?def\s+(self\.)?([\w?!=]+|\[\]=?|[+\-*/%<>=!~^&|]+)`)
+	rubyEndlessDefPattern = regexp.MustCompile(`^(?:(?:private|protected|public)\s+)?def\s+[\w.?!]+(?:\([^)]*\))?\s*=[^=~]`)
+	rubyAttrPattern       = regexp.MustCompile(`^attr_(accessor|reader|writer)\s+(.+)`)
+	rubySymbolPattern     = regexp.MustCompile(`:(\w+)`)
+	rubyVisibilityPattern = regexp.MustCompile(`^(private|protected|public)\s*$`)
+	rubyOpenerPattern     = regexp.MustCompile(`^(?:class|module|def|if|unless|while|until|case|begin|for)\b|=\s*(?:if|unless|case|begin)\b`)
+	rubyDoPattern         = regexp.MustCompile(`\bdo\s*(?:\|[^|]*\|)?\s*$`)
+	rubyEndPattern        = regexp.MustCompile(`(?:^|[^.:\w])end\b`)
+)
+
+// rubyScope is an open block in Ruby source, closed by a matching "end"
+type rubyScope struct {
+	kind       string // class, module, singleton, def or block
+	name       string
+	visibility string
+}
+
+// parseRubyFile extracts requires, modules, classes, methods (including
+// self. methods) and attribute accessors from Ruby source
+func (s *Summarizer) parseRubyFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	original := strings.Split(string(content), "\n")
+	stripped := strings.Split(stripComments(string(content), false, true), "\n")
+	summary.LineCount = len(original)
+
+	var stack []rubyScope
+	inDocComment := false
+
+	// owner returns the innermost class or module and its stack index
+	owner := func() (int, bool) {
+		for i := len(stack) - 1; i >= 0; i-- {
+			switch stack[i].kind {
+			case "class", "module", "singleton":
+				return i, true
+			case "def":
+				return 0, false
+			}
+		}
+		return 0, false
+	}
+
+	for i, rawLine := range stripped {
+		line := strings.TrimSpace(rawLine)
+
+		// =begin/=end block comments
+		if strings.HasPrefix(original[i], "=begin") {
+			inDocComment = true
+		}
+		if inDocComment {
+			if strings.HasPrefix(original[i], "=end") {
+				inDocComment = false
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		if matches := rubyRequirePattern.FindStringSubmatch(original[i]); matches != nil {
+			summary.Imports = append(summary.Imports, matches[2])
+			continue
+		}
+
+		opened := false
+		switch {
+		case rubySingletonPattern.MatchString(line):
+			index, ok := owner()
+			name := ""
+			if ok {
+				name = stack[index].name
+			}
+			stack = append(stack, rubyScope{kind: "singleton", name: name, visibility: "+ "})
+			opened = true
+
+		case rubyTypePattern.MatchString(line):
+			matches := rubyTypePattern.FindStringSubmatch(line)
+			name := matches[2]
+			if index, ok := owner(); ok && stack[index].kind != "singleton" {
+				name = stack[index].name + "::" + name
+			}
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", name, matches[1]))
+			if matches[1] == "class" {
+				summary.Structs = append(summary.Structs, name)
+			}
+			stack = append(stack, rubyScope{kind: matches[1], name: name, visibility: "+ "})
+			opened = true
+
+		case rubyDefPattern.MatchString(line):
+			matches := rubyDefPattern.FindStringSubmatch(line)
+			name := matches[3]
+			if index, ok := owner(); ok {
+				scope := stack[index]
+				if matches[2] != "" || scope.kind == "singleton" {
+					name = "self." + name
+				}
+				visibility := scope.visibility
+				if matches[1] != "" {
+					visibility = accessMarker(matches[1], "+ ")
+				}
+				summary.Members[scope.name] = append(summary.Members[scope.name], visibility+name)
+			} else {
+				summary.Functions = append(summary.Functions, name)
+			}
+			summary.FunctionCount++
+
+			if !rubyEndlessDefPattern.MatchString(line) {
+				stack = append(stack, rubyScope{kind: "def"})
+				opened = true
+			}
+
+		case rubyAttrPattern.MatchString(line):
+			if index, ok := owner(); ok {
+				matches := rubyAttrPattern.FindStringSubmatch(line)
+				scope := stack[index]
+				for _, symbol := range rubySymbolPattern.FindAllStringSubmatch(matches[2], -1) {
+					entry := fmt.Sprintf("%s%s (attr_%s)", scope.visibility, symbol[1], matches[1])
+					summary.Members[scope.name] = append(summary.Members[scope.name], entry)
+				}
+			}
+
+		case rubyVisibilityPattern.MatchString(line):
+			if index, ok := owner(); ok {
+				stack[index].visibility = accessMarker(line, "+ ")
+			}
+
+		case rubyOpenerPattern.MatchString(line):
+			stack = append(stack, rubyScope{kind: "block"})
+			opened = true
+		}
+
+		if !opened && rubyDoPattern.MatchString(line) {
+			stack = append(stack, rubyScope{kind: "block"})
+		}
+
+		// Close blocks, including one-liners such as "def x; end"
+		for range rubyEndPattern.FindAllString(line, -1) {
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return summary
+}
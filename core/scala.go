@@ -0,0 +1,124 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// scalaModifiers is the alternation of Scala declaration modifiers, including
+// qualified access such as private[pkg]
+const scalaModifiers = `(?:private|protected)(?:\[\w+\])?|final|sealed|abstract|implicit|lazy|override|case|inline|transparent|open|opaque`
+
+// Scala declaration patterns, applied to comment-stripped lines
+var (
+	scalaPackagePattern    = regexp.MustCompile(`^package\s+([\w.]+)`)
+	scalaImportPattern     = regexp.MustCompile(`^import\s+(.+?)\s*;?$`)
+	scalaAnnotationPattern = regexp.MustCompile(`^@(\w+)(?:\([^)]*\))?\s*`)
+	scalaTypePattern       = regexp.MustCompile(`^((?:(?:` + scalaModifiers + `)\s+)*)(class|trait|object|enum)\s+(\w+)`)
+	scalaDefPattern        = regexp.MustCompile(`^((?:(?:` + scalaModifiers + `)\s+)*)def\s+(\w+|[^\s\[(:\w]+)`)
+)
+
+// parseScalaFile extracts the package, imports, objects, classes, traits,
+// case classes and defs from Scala source
+func (s *Summarizer) parseScalaFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(stripCComments(string(content)), "\n")
+	summary.LineCount = len(lines)
+
+	var scopes scopeStack
+	var packages []string
+	annotationCounts := make(map[string]int)
+	var annotationOrder []string
+	depth := 0
+
+	// addDef records a def of the enclosing type or a top-level def
+	addDef := func(line string, lineDepth int) {
+		matches := scalaDefPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return
+		}
+		entry := accessMarker(matches[1], "+ ") + matches[2]
+		if owner, ok := scopes.current(); ok && owner.opened && lineDepth == owner.depth+1 {
+			summary.Members[owner.name] = append(summary.Members[owner.name], entry)
+			summary.FunctionCount++
+		} else if !ok && lineDepth == 0 {
+			// Scala 3 allows top-level definitions
+			summary.Functions = append(summary.Functions, entry)
+			summary.FunctionCount++
+		}
+	}
+
+	for i, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		lineDepth := depth
+		depth += braceDelta(line)
+
+		if line == "" {
+			continue
+		}
+
+		if matches := scalaPackagePattern.FindStringSubmatch(line); matches != nil {
+			packages = append(packages, matches[1])
+			continue
+		}
+
+		if matches := scalaImportPattern.FindStringSubmatch(line); matches != nil && len(scopes) == 0 {
+			summary.Imports = append(summary.Imports, matches[1])
+			continue
+		}
+
+		for {
+			matches := scalaAnnotationPattern.FindStringSubmatch(line)
+			if matches == nil {
+				break
+			}
+			name := "@" + matches[1]
+			if annotationCounts[name] == 0 {
+				annotationOrder = append(annotationOrder, name)
+			}
+			annotationCounts[name]++
+			line = line[len(matches[0]):]
+		}
+
+		if matches := scalaTypePattern.FindStringSubmatch(line); matches != nil {
+			kind := matches[2]
+			if strings.Contains(" "+matches[1], " case ") {
+				kind = "case " + kind
+			}
+			name := scopes.qualifiedName(matches[3])
+			entry := accessMarker(matches[1], "+ ") + name
+
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", entry, kind))
+			switch kind {
+			case "class", "case class":
+				summary.Structs = append(summary.Structs, entry)
+			case "trait":
+				summary.Interfaces = append(summary.Interfaces, entry)
+			}
+
+			if declarationHasBody(lines, i) {
+				scopes = scopes.push(name, kind, lineDepth, strings.Contains(line, "{"))
+				// Members of a body written on the declaration line
+				for _, member := range bodyMembers(line) {
+					addDef(member, lineDepth+1)
+				}
+			}
+			scopes = scopes.update(depth)
+			continue
+		}
+
+		addDef(line, lineDepth)
+		scopes = scopes.update(depth)
+	}
+
+	summary.Package = strings.Join(packages, ".")
+	summary.Annotations = formatCounts(annotationOrder, annotationCounts)
+	return summary
+}
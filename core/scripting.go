@@ -0,0 +1,19 @@
+package core
+
+import "regexp"
+
+// Line-based patterns for scripting languages that need no more than the
+// generic regex parser
+var (
+	perlConfig = LanguageConfig{
+		FunctionPattern: regexp.MustCompile(`^sub\s+(\w+)`),
+		ImportPattern:   regexp.MustCompile(`^(?:use|require)\s+([\w:]+)`),
+		TypePattern:     regexp.MustCompile(`^package\s+([\w:]+)`),
+	}
+
+	luaConfig = LanguageConfig{
+		FunctionPattern: regexp.MustCompile(`^(?:local\s+)?function\s+([\w.:]+)`),
+		ImportPattern:   regexp.MustCompile(`require\s*\(?\s*['"]([^'"]+)['"]`),
+		TypePattern:     regexp.MustCompile(`^(?:local\s+)?(\w+)\s*=\s*(?:setmetatable\s*\(\s*)?\{\s*\}`),
+	}
+)
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Shell script patterns, applied to comment-stripped lines
+var (
+	shellFunctionPattern  = regexp.MustCompile(`^(?:function\s+([\w:.-]+)\s*(?:\(\s*\))?|([\w:.-]+)\s*\(\s*\))\s*(?:\{|\(|$)`)
+	fishFunctionPattern   = regexp.MustCompile(`^function\s+([\w:.-]+)`)
+	shellSourcePattern    = regexp.MustCompile(`^(?:source|\.)\s+(\S+)`)
+	shellExportPattern    = regexp.MustCompile(`^(?:export|declare\s+-\w*x\w*|typeset\s+-\w*x\w*)\s+(.+)`)
+	fishExportPattern     = regexp.MustCompile(`^set\s+(?:-\w*x\w*\s+)+(\w+)`)
+	shellGetoptsPattern   = regexp.MustCompile(`getopts\s+['"]?:?([\w:]+)['"]?`)
+	shellCasePattern      = regexp.MustCompile(`^case\s.*\sin\b`)
+	shellCaseArmPattern   = regexp.MustCompile(`^\(?\s*([^()\s|]+(?:\s*\|\s*[^()\s|]+)*)\s*\)`)
+	shellEsacPattern      = regexp.MustCompile(`(?:^|[;\s])esac\b`)
+	shellHeredocPattern   = regexp.MustCompile(`<<-?\s*['"]?(\w+)['"]?`)
+	shellAssignmentPrefix = regexp.MustCompile(`^(?:\w+=\S*\s+)+`)
+	shellCommandSplit     = regexp.MustCompile(`\|\||&&|[|;&]|\$\(|` + "`")
+	shellNamePattern      = regexp.MustCompile(`^\w+$`)
+	shellCommandPattern   = regexp.MustCompile(`^[\w./+-]+$`)
+	shebangPattern        = regexp.MustCompile(`^#!\s*(\S+)(?:\s+(\S+))?`)
+)
+
+// shellBuiltins are keywords and builtins that are not reported as commands
+var shellBuiltins = map[string]bool{
+	"if": true, "then": true, "else": true, "elif": true, "fi": true, "for": true, "while": true,
+	"until": true, "do": true, "done": true, "case": true, "esac": true, "in": true, "function": true,
+	"return": true, "exit": true, "break": true, "continue": true, "local": true, "export": true,
+	"declare": true, "typeset": true, "readonly": true, "set": true, "unset": true, "shift": true,
+	"source": true, ".": true, "echo": true, "printf": true, "read": true, "cd": true, "test": true,
+	"[": true, "[[": true, "]]": true, "true": true, "false": true, "eval": true, "exec": true,
+	"trap": true, "wait": true, "getopts": true, "let": true, "{": true, "}": true, "(": true,
+	")": true, "!": true, "end": true, "not": true, "and": true, "or": true, "switch": true,
+	"command": true, "builtin": true, "pushd": true, "popd": true, "alias": true, "type": true,
+}
+
+// shellInterpreters maps a shebang interpreter to the language it runs
+var shellInterpreters = map[string]string{
+	"sh":   "Shell",
+	"dash": "Shell",
+	"ash":  "Shell",
+	"ksh":  "Shell",
+	"bash": "Bash",
+	"zsh":  "Zsh",
+	"fish": "Fish",
+}
+
+// shebangInterpreter returns the program named on a #! line, resolving
+// "#!/usr/bin/env bash" to "bash"
+func shebangInterpreter(line string) string {
+	matches := shebangPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return ""
+	}
+	interpreter := filepath.Base(matches[1])
+	if interpreter == "env" && matches[2] != "" && !strings.HasPrefix(matches[2], "-") {
+		interpreter = matches[2]
+	}
+	return interpreter
+}
+
+// shebangSniffer matches files whose #! line names one of the interpreters,
+// allowing version suffixes such as python3
+func shebangSniffer(interpreters ...string) func(head []byte) bool {
+	return func(head []byte) bool {
+		if !bytes.HasPrefix(head, []byte("#!")) {
+			return false
+		}
+		firstLine, _, _ := bytes.Cut(head, []byte("\n"))
+		interpreter := strings.TrimRight(shebangInterpreter(string(firstLine)), "0123456789.")
+		for _, candidate := range interpreters {
+			if interpreter == candidate {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// parseShellFile statically summarizes sh/bash/zsh/fish scripts: the shebang,
+// functions, sourced files, exported variables, invoked commands and flags.
+// Scripts are never executed.
+func (s *Summarizer) parseShellFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(string(content), "\n")
+	summary.LineCount = len(lines)
+
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "#!") {
+		summary.Shebang = strings.TrimSpace(lines[0])
+		if language, ok := shellInterpreters[shebangInterpreter(lines[0])]; ok && summary.Language == "Shell" {
+			summary.Language = language
+		}
+	}
+
+	functions := make(map[string]bool)
+	commandCounts := make(map[string]int)
+	var commandOrder []string
+	seenFlags := make(map[string]bool)
+	heredocEnd := ""
+	caseDepth := 0
+
+	addFlag := func(flag string) {
+		if !seenFlags[flag] {
+			seenFlags[flag] = true
+			summary.Flags = append(summary.Flags, flag)
+		}
+	}
+
+	for _, rawLine := range lines {
+		// Skip heredoc bodies until their terminator
+		if heredocEnd != "" {
+			if strings.TrimSpace(rawLine) == heredocEnd {
+				heredocEnd = ""
+			}
+			continue
+		}
+
+		line := strings.TrimSpace(stripShellComment(rawLine))
+		if line == "" {
+			continue
+		}
+		if matches := shellHeredocPattern.FindStringSubmatch(line); matches != nil {
+			heredocEnd = matches[1]
+		}
+
+		functionMatches := shellFunctionPattern.FindStringSubmatch(line)
+		if functionMatches == nil && summary.Language == "Fish" {
+			functionMatches = fishFunctionPattern.FindStringSubmatch(line)
+		}
+		if functionMatches != nil {
+			name := strings.Join(functionMatches[1:], "")
+			if !functions[name] {
+				functions[name] = true
+				summary.Functions = append(summary.Functions, name)
+				summary.FunctionCount++
+			}
+			continue
+		}
+
+		if matches := shellSourcePattern.FindStringSubmatch(line); matches != nil {
+			summary.Imports = append(summary.Imports, strings.Trim(matches[1], `"'`))
+			continue
+		}
+
+		if matches := shellExportPattern.FindStringSubmatch(line); matches != nil {
+			for _, field := range strings.Fields(matches[1]) {
+				name, _, _ := strings.Cut(field, "=")
+				if shellNamePattern.MatchString(name) {
+					summary.Variables = append(summary.Variables, name)
+				}
+			}
+			continue
+		}
+		if matches := fishExportPattern.FindStringSubmatch(line); matches != nil {
+			summary.Variables = append(summary.Variables, matches[1])
+		}
+
+		if matches := shellGetoptsPattern.FindStringSubmatch(line); matches != nil {
+			spec := matches[1]
+			for i := 0; i < len(spec); i++ {
+				if spec[i] == ':' {
+					continue
+				}
+				flag := "-" + string(spec[i])
+				if i+1 < len(spec) && spec[i+1] == ':' {
+					flag += " <arg>"
+				}
+				addFlag(flag)
+			}
+		}
+
+		// Case arms whose patterns are all options, e.g. -h|--help)
+		if caseDepth > 0 {
+			if matches := shellCaseArmPattern.FindStringSubmatch(line); matches != nil {
+				patterns := strings.Split(matches[1], "|")
+				options := true
+				for _, pattern := range patterns {
+					options = options && strings.HasPrefix(strings.Trim(strings.TrimSpace(pattern), `"'`), "-")
+				}
+				if options {
+					for _, pattern := range patterns {
+						addFlag(strings.TrimSuffix(strings.Trim(strings.TrimSpace(pattern), `"'`), "*"))
+					}
+				}
+				// The arm's commands follow the closing parenthesis
+				line = strings.TrimSpace(line[len(matches[0]):])
+			}
+		}
+		if shellCasePattern.MatchString(line) {
+			caseDepth++
+			continue
+		}
+		caseDepth -= len(shellEsacPattern.FindAllString(line, -1))
+		if caseDepth < 0 {
+			caseDepth = 0
+		}
+
+		for _, segment := range shellCommandSplit.Split(line, -1) {
+			segment = shellAssignmentPrefix.ReplaceAllString(strings.TrimSpace(segment), "")
+			fields := strings.Fields(segment)
+			if len(fields) == 0 {
+				continue
+			}
+			command := strings.Trim(fields[0], `"'()`)
+			if command == "" || shellBuiltins[command] || strings.Contains(command, "=") ||
+				strings.HasPrefix(command, "$") || strings.HasPrefix(command, "-") ||
+				!shellCommandPattern.MatchString(command) {
+				continue
+			}
+			if commandCounts[command] == 0 {
+				commandOrder = append(commandOrder, command)
+			}
+			commandCounts[command]++
+		}
+	}
+
+	// Calls to the script's own functions are not external commands
+	var commands []string
+	for _, command := range commandOrder {
+		if !functions[command] {
+			commands = append(commands, command)
+		}
+	}
+	summary.Commands = formatCounts(commands, commandCounts)
+
+	return summary
+}
+
+// stripShellComment removes a trailing # comment that is not inside quotes
+// or part of a parameter expansion such as $# or ${#var}
+func stripShellComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\\':
+			i++
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t' || line[i-1] == ';'):
+			return line[:i]
+		}
+	}
+	return line
+}
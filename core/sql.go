@@ -0,0 +1,578 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SQLSummary describes the statements and schema objects of a SQL file
+type SQLSummary struct {
+	Migration  string     // Recognized migration convention, e.g. "golang-migrate up, version 3"
+	Statements int        // Total statements
+	Categories []string   // Statement totals by category, e.g. "DDL: 4"
+	Kinds      []string   // Statement kinds with counts, e.g. "CREATE TABLE (2)"
+	Tables     []SQLTable // Tables created, with their columns
+	Altered    []string   // ALTER TABLE changes, e.g. "users: ADD COLUMN age"
+	Dropped    []string   // Objects dropped
+	Views      []string   // Views and materialized views created
+	Indexes    []string   // Indexes, e.g. "idx_email ON users (email) unique"
+	Routines   []string   // Functions, procedures and triggers
+	Objects    []string   // Other objects: types, sequences, schemas, extensions
+}
+
+// SQLTable is a table created by CREATE TABLE
+type SQLTable struct {
+	Name    string
+	Columns []string // "name type" with PK/FK markers
+}
+
+// sqlCategories maps a statement's leading keyword to its category
+var sqlCategories = map[string]string{
+	"CREATE": "DDL", "ALTER": "DDL", "DROP": "DDL", "TRUNCATE": "DDL", "RENAME": "DDL", "COMMENT": "DDL",
+	"INSERT": "DML", "UPDATE": "DML", "DELETE": "DML", "MERGE": "DML", "UPSERT": "DML", "REPLACE": "DML", "COPY": "DML",
+	"SELECT": "Query", "WITH": "Query", "VALUES": "Query", "SHOW": "Query", "EXPLAIN": "Query", "DESCRIBE": "Query",
+	"GRANT": "DCL", "REVOKE": "DCL",
+	"BEGIN": "Transaction", "COMMIT": "Transaction", "ROLLBACK": "Transaction", "START": "Transaction", "SAVEPOINT": "Transaction", "END": "Transaction",
+}
+
+// sqlCategoryOrder is the order categories are listed in
+var sqlCategoryOrder = []string{"DDL", "DML", "Query", "DCL", "Transaction", "Other"}
+
+var (
+	// sqlObjectPattern matches the object type and name of CREATE, ALTER and DROP statements
+	sqlObjectPattern = regexp.MustCompile(`(?is)^(CREATE|ALTER|DROP)\s+(?:OR\s+REPLACE\s+|OR\s+ALTER\s+)?(?:(?:GLOBAL|LOCAL)\s+)?(?:TEMP\s+|TEMPORARY\s+|UNLOGGED\s+|UNIQUE\s+|MATERIALIZED\s+|RECURSIVE\s+|VIRTUAL\s+|DEFINER\s*=\s*\S+\s+)*` +
+		`(TABLE|VIEW|INDEX|FUNCTION|PROCEDURE|TRIGGER|TYPE|SEQUENCE|SCHEMA|EXTENSION|DATABASE|DOMAIN|POLICY|ROLE)\s+` +
+		`(?:CONCURRENTLY\s+)?(?:IF\s+(?:NOT\s+)?EXISTS\s+)?(?:ONLY\s+)?([^\s(;]+)`)
+	// sqlIndexTargetPattern matches the table of CREATE INDEX, up to its column list
+	sqlIndexTargetPattern = regexp.MustCompile(`(?is)\bON\s+(?:ONLY\s+)?([^\s(]+)\s*(?:USING\s+\w+\s*)?\(`)
+	// sqlReturnsPattern matches the return type of a function
+	sqlReturnsPattern = regexp.MustCompile(`(?is)\)\s*RETURNS\s+(SETOF\s+\S+|TABLE\s*\([^)]*\)|[^\s(]+(?:\([^)]*\))?)`)
+	// sqlTriggerPattern matches the timing, event and table of CREATE TRIGGER
+	sqlTriggerPattern = regexp.MustCompile(`(?is)\b(BEFORE|AFTER|INSTEAD\s+OF)\s+(.+?)\s+ON\s+([^\s(]+)`)
+	// sqlCreateAsPattern matches CREATE TABLE ... AS SELECT
+	sqlCreateAsPattern = regexp.MustCompile(`(?i)\bAS\b`)
+	// sqlDelimiterPattern matches MySQL client DELIMITER commands
+	sqlDelimiterPattern = regexp.MustCompile(`(?i)^\s*DELIMITER\s+(\S+)\s*$`)
+	// sqlMySQLPattern matches syntax only found in MySQL and MariaDB, whose
+	// strings treat backslash as an escape character
+	sqlMySQLPattern = regexp.MustCompile(`(?im)` + "`\\w+`" + `|\bENGINE\s*=|\bAUTO_INCREMENT\b|^/\*!\d+|^--\s*MySQL dump|^LOCK TABLES\b`)
+	// sqlWordPattern matches identifiers and keywords
+	sqlWordPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// Migration file names understood by common tools
+var (
+	golangMigratePattern   = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+	flywayPattern          = regexp.MustCompile(`^([VUR])([\d._]*)__(.+)\.sql$`)
+	timestampPattern       = regexp.MustCompile(`^(\d{8,14}|\d{1,6})[_-](.+)\.sql$`)
+	migrationMarkerPattern = regexp.MustCompile(`(?im)^--\s*(\+goose\s+|\+migrate\s+|migrate:)(Up|Down)\b.*$`)
+)
+
+// parseSQL splits a SQL file into statements, counting them by kind and
+// listing the tables, views, indexes and routines they define
+func (s *Summarizer) parseSQL(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	sql := &SQLSummary{Migration: sqlMigration(filepath.Base(fullPath), string(content))}
+	kindCounts := make(map[string]int)
+	var kindOrder []string
+	categoryCounts := make(map[string]int)
+	tables := make(map[string]int) // Index into sql.Tables
+
+	for _, statement := range splitSQLStatements(string(content)) {
+		words := sqlWordPattern.FindAllString(statement, 3)
+		if len(words) == 0 {
+			continue
+		}
+		sql.Statements++
+		keyword := strings.ToUpper(words[0])
+		category, known := sqlCategories[keyword]
+		if !known {
+			category = "Other"
+		}
+		categoryCounts[category]++
+
+		kind := keyword
+		matches := sqlObjectPattern.FindStringSubmatch(statement)
+		if matches != nil {
+			kind = strings.ToUpper(matches[1] + " " + matches[2])
+		}
+		if kindCounts[kind] == 0 {
+			kindOrder = append(kindOrder, kind)
+		}
+		kindCounts[kind]++
+
+		if matches == nil {
+			continue
+		}
+		name := strings.Trim(matches[3], "`\"[]")
+		switch strings.ToUpper(matches[1]) + " " + strings.ToUpper(matches[2]) {
+		case "CREATE TABLE":
+			table := SQLTable{Name: name, Columns: sqlColumns(statement[len(matches[0]):])}
+			if index, exists := tables[strings.ToLower(name)]; exists {
+				sql.Tables[index] = table
+			} else {
+				tables[strings.ToLower(name)] = len(sql.Tables)
+				sql.Tables = append(sql.Tables, table)
+			}
+		case "ALTER TABLE":
+			sql.Altered = append(sql.Altered, sqlAlterations(name, statement)...)
+		case "CREATE VIEW":
+			view := name
+			if strings.Contains(strings.ToUpper(matches[0]), "MATERIALIZED") {
+				view += " (materialized)"
+			}
+			sql.Views = append(sql.Views, view)
+		case "CREATE INDEX":
+			index := name
+			if target := sqlIndexTargetPattern.FindStringSubmatchIndex(statement); target != nil {
+				index += fmt.Sprintf(" ON %s %s", statement[target[2]:target[3]], sqlParameters(statement[target[1]-1:]))
+			}
+			if strings.Contains(strings.ToUpper(matches[0]), "UNIQUE") {
+				index += " unique"
+			}
+			sql.Indexes = append(sql.Indexes, index)
+		case "CREATE FUNCTION", "CREATE PROCEDURE":
+			routine := strings.ToLower(matches[2]) + " " + name + sqlParameters(statement[len(matches[0]):])
+			if returns := sqlReturnsPattern.FindStringSubmatch(statement); returns != nil {
+				routine += " → " + normalizeSpace(returns[1])
+			}
+			sql.Routines = append(sql.Routines, routine)
+		case "CREATE TRIGGER":
+			routine := "trigger " + name
+			if trigger := sqlTriggerPattern.FindStringSubmatch(statement); trigger != nil {
+				routine += fmt.Sprintf(" %s %s ON %s", strings.ToUpper(normalizeSpace(trigger[1])), strings.ToUpper(normalizeSpace(trigger[2])), trigger[3])
+			}
+			sql.Routines = append(sql.Routines, routine)
+		default:
+			if strings.EqualFold(matches[1], "DROP") {
+				sql.Dropped = append(sql.Dropped, strings.ToLower(matches[2])+" "+name)
+			} else if strings.EqualFold(matches[1], "CREATE") {
+				sql.Objects = append(sql.Objects, strings.ToLower(matches[2])+" "+name)
+			}
+		}
+	}
+
+	sql.Kinds = formatCounts(kindOrder, kindCounts)
+	for _, category := range sqlCategoryOrder {
+		if categoryCounts[category] > 0 {
+			sql.Categories = append(sql.Categories, fmt.Sprintf("%s: %d", category, categoryCounts[category]))
+		}
+	}
+
+	summary.SQL = sql
+	return summary
+}
+
+// splitSQLStatements splits SQL text on semicolons outside of strings, quoted
+// identifiers, comments and dollar-quoted bodies. BEGIN ... END blocks of
+// routines, MySQL DELIMITER commands and T-SQL GO separators are honored.
+func splitSQLStatements(content string) []string {
+	var statements []string
+	var current strings.Builder
+	delimiter := ";"
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	var quote byte        // Open ', " or ` quote
+	quoteEscapes := false // Whether backslash escapes the next byte in the open quote
+	dollarTag := ""       // Open $tag$ quote
+	inBlockComment := false
+	mysql := sqlMySQLPattern.MatchString(content)
+
+	for _, line := range lines {
+		// Client commands are only recognized on a line of their own
+		if quote == 0 && dollarTag == "" && !inBlockComment {
+			if matches := sqlDelimiterPattern.FindStringSubmatch(line); matches != nil {
+				flush()
+				delimiter = matches[1]
+				continue
+			}
+			if strings.EqualFold(strings.TrimSpace(line), "GO") {
+				flush()
+				continue
+			}
+		}
+
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			switch {
+			case inBlockComment:
+				if strings.HasPrefix(line[i:], "*/") {
+					inBlockComment = false
+					i++
+				}
+				continue
+			case quote != 0:
+				current.WriteByte(c)
+				switch {
+				case c == '\\' && quoteEscapes && i+1 < len(line):
+					// MySQL and PostgreSQL E'' strings escape quotes with a
+					// backslash, as in 'O\'Brien'
+					i++
+					current.WriteByte(line[i])
+				case c == quote && i+1 < len(line) && line[i+1] == quote:
+					// A doubled quote stands for the quote itself
+					i++
+					current.WriteByte(line[i])
+				case c == quote:
+					quote = 0
+				}
+				continue
+			case dollarTag != "":
+				if strings.HasPrefix(line[i:], dollarTag) {
+					current.WriteString(dollarTag)
+					i += len(dollarTag) - 1
+					dollarTag = ""
+				} else {
+					current.WriteByte(c)
+				}
+				continue
+			}
+
+			switch {
+			case delimiter != ";" && strings.HasPrefix(line[i:], delimiter):
+				// A custom delimiter such as $$ takes precedence over dollar quotes
+				flush()
+				i += len(delimiter) - 1
+				continue
+			case strings.HasPrefix(line[i:], "--") || c == '#' && strings.TrimSpace(current.String()) == "":
+				// Line comments run to the end of the line
+				i = len(line)
+				current.WriteByte('\n')
+				continue
+			case strings.HasPrefix(line[i:], "/*"):
+				inBlockComment = true
+				i++
+				continue
+			case c == '\'' || c == '"' || c == '`':
+				quote = c
+				quoteEscapes = mysql && c != '`' || c == '\'' && sqlEscapeStringPrefix(line[:i])
+			case c == '$':
+				if tag := sqlDollarTag(line[i:]); tag != "" {
+					dollarTag = tag
+					current.WriteString(tag)
+					i += len(tag) - 1
+					continue
+				}
+			case c == ';' && delimiter == ";":
+				if sqlInsideBlock(current.String()) {
+					break
+				}
+				flush()
+				continue
+			}
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
+
+// sqlEscapeStringPrefix reports whether text ends with the E of a PostgreSQL
+// escape string constant such as E'line\n'
+func sqlEscapeStringPrefix(text string) bool {
+	if !strings.HasSuffix(text, "E") && !strings.HasSuffix(text, "e") {
+		return false
+	}
+	text = text[:len(text)-1]
+	if text == "" {
+		return true
+	}
+	last := text[len(text)-1]
+	return !(last == '_' || last >= '0' && last <= '9' || last >= 'A' && last <= 'Z' || last >= 'a' && last <= 'z')
+}
+
+// sqlDollarTag returns the PostgreSQL dollar-quote tag at the start of text,
+// such as "$$" or "$body$", or ""
+func sqlDollarTag(text string) string {
+	end := strings.IndexByte(text[1:], '$')
+	if end < 0 {
+		return ""
+	}
+	tag := text[:end+2]
+	for _, r := range tag[1 : len(tag)-1] {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return ""
+		}
+	}
+	return tag
+}
+
+// sqlInsideBlock reports whether a semicolon ends a line of a routine body
+// rather than the statement, by counting BEGIN and CASE against END
+func sqlInsideBlock(statement string) bool {
+	words := sqlWordPattern.FindAllString(statement, -1)
+	if len(words) < 2 || !strings.EqualFold(words[0], "CREATE") {
+		return false
+	}
+	routine := false
+	for _, word := range words[1:min(len(words), 6)] {
+		switch strings.ToUpper(word) {
+		case "FUNCTION", "PROCEDURE", "TRIGGER", "EVENT":
+			routine = true
+		}
+	}
+	if !routine {
+		return false
+	}
+
+	depth := 0
+	for i, word := range words {
+		switch strings.ToUpper(word) {
+		case "BEGIN", "CASE":
+			depth++
+		case "END":
+			// END IF, END LOOP and friends close statements, not blocks
+			if i+1 < len(words) {
+				switch strings.ToUpper(words[i+1]) {
+				case "IF", "LOOP", "WHILE", "REPEAT":
+					continue
+				}
+			}
+			depth--
+		}
+	}
+	return depth > 0
+}
+
+// sqlColumns lists the columns defined after the name in CREATE TABLE,
+// marking primary and foreign keys declared inline or as table constraints
+func sqlColumns(definition string) []string {
+	open := strings.IndexByte(definition, '(')
+	if open < 0 || sqlCreateAsPattern.MatchString(definition[:open]) {
+		return nil
+	}
+	list := sqlParameters(definition[open:])
+	if list == "" {
+		return nil
+	}
+	definitions := splitTopLevel(list[1 : len(list)-1])
+
+	var names []string
+	columns := make(map[string]string)
+	primary := make(map[string]bool)
+	foreign := make(map[string]string)
+	for _, definition := range definitions {
+		definition = normalizeSpace(definition)
+		fields := strings.Fields(definition)
+		if len(fields) == 0 {
+			continue
+		}
+		upper := strings.ToUpper(definition)
+		switch strings.ToUpper(fields[0]) {
+		case "CONSTRAINT", "PRIMARY", "FOREIGN", "UNIQUE", "CHECK", "INDEX", "KEY", "EXCLUDE", "FULLTEXT", "SPATIAL", "PERIOD", "LIKE":
+			// Table constraints name their columns in parentheses
+			if index := strings.Index(upper, "PRIMARY KEY"); index >= 0 {
+				for _, column := range sqlParenthesized(definition[index:]) {
+					primary[strings.ToLower(column)] = true
+				}
+			}
+			if index := strings.Index(upper, "FOREIGN KEY"); index >= 0 {
+				if reference := strings.Index(upper, "REFERENCES"); reference >= 0 {
+					target := strings.Fields(definition[reference+len("REFERENCES"):])
+					for _, column := range sqlParenthesized(definition[index:reference]) {
+						if len(target) > 0 {
+							foreign[strings.ToLower(column)] = strings.SplitN(target[0], "(", 2)[0]
+						}
+					}
+				}
+			}
+			continue
+		}
+
+		name := strings.Trim(fields[0], "`\"[]")
+		column := name
+		if len(fields) > 1 {
+			column += " " + sqlColumnType(fields[1:])
+		}
+		if strings.Contains(upper, "PRIMARY KEY") {
+			primary[strings.ToLower(name)] = true
+		}
+		if reference := strings.Index(upper, "REFERENCES"); reference >= 0 {
+			if target := strings.Fields(definition[reference+len("REFERENCES"):]); len(target) > 0 {
+				foreign[strings.ToLower(name)] = strings.SplitN(target[0], "(", 2)[0]
+			}
+		}
+		names = append(names, name)
+		columns[name] = column
+	}
+
+	var result []string
+	for _, name := range names {
+		column := columns[name]
+		if primary[strings.ToLower(name)] {
+			column += " PK"
+		}
+		if target := foreign[strings.ToLower(name)]; target != "" {
+			column += " FK → " + target
+		}
+		result = append(result, column)
+	}
+	return result
+}
+
+// sqlColumnType returns the data type at the start of a column definition,
+// stopping at the first constraint keyword
+func sqlColumnType(fields []string) string {
+	var parts []string
+	for _, field := range fields {
+		switch strings.ToUpper(field) {
+		case "NOT", "NULL", "PRIMARY", "REFERENCES", "DEFAULT", "UNIQUE", "CHECK", "CONSTRAINT",
+			"GENERATED", "AUTO_INCREMENT", "AUTOINCREMENT", "COLLATE", "COMMENT", "IDENTITY", "ON":
+			return strings.Join(parts, " ")
+		}
+		parts = append(parts, field)
+	}
+	return strings.Join(parts, " ")
+}
+
+// sqlAlterations lists the changes made by an ALTER TABLE statement, such
+// as "users: ADD COLUMN age"
+func sqlAlterations(table, statement string) []string {
+	index := strings.Index(strings.ToUpper(statement), strings.ToUpper(table))
+	if index < 0 {
+		return []string{table}
+	}
+
+	var changes []string
+	for _, action := range splitTopLevel(statement[index+len(table):]) {
+		fields := strings.Fields(action)
+		if len(fields) == 0 {
+			continue
+		}
+		// Keep the action, the kind of object and its name
+		words := []string{strings.ToUpper(fields[0])}
+		for _, field := range fields[1:] {
+			upper := strings.ToUpper(field)
+			switch upper {
+			case "COLUMN", "CONSTRAINT", "INDEX", "KEY", "PRIMARY", "FOREIGN", "UNIQUE", "TO", "IF", "EXISTS", "NOT", "OWNER", "SET", "TYPE", "DEFAULT":
+				words = append(words, upper)
+				continue
+			}
+			words = append(words, strings.Trim(field, "`\"[]"))
+			break
+		}
+		changes = append(changes, table+": "+strings.Join(words, " "))
+	}
+	return changes
+}
+
+// splitTopLevel splits text on commas outside of parentheses
+func splitTopLevel(text string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// sqlParameters returns the parenthesized list at the start of text, or ""
+func sqlParameters(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "(") {
+		return ""
+	}
+	depth := 0
+	for i, c := range text {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return normalizeSpace(text[:i+1])
+			}
+		}
+	}
+	return ""
+}
+
+// sqlParenthesized returns the comma-separated names inside the first
+// parentheses of text
+func sqlParenthesized(text string) []string {
+	open := strings.IndexByte(text, '(')
+	end := strings.IndexByte(text, ')')
+	if open < 0 || end < open {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(text[open+1:end], ",") {
+		names = append(names, strings.Trim(strings.TrimSpace(name), "`\"[]"))
+	}
+	return names
+}
+
+// sqlMigration recognizes migration files by the naming conventions of
+// golang-migrate, Flyway and timestamped migrations, and by the up/down
+// markers of goose, sql-migrate and dbmate
+func sqlMigration(name, content string) string {
+	var migration string
+	switch {
+	case golangMigratePattern.MatchString(name):
+		matches := golangMigratePattern.FindStringSubmatch(name)
+		migration = fmt.Sprintf("%s migration %s: %s", matches[3], matches[1], humanizeName(matches[2]))
+	case flywayPattern.MatchString(name):
+		matches := flywayPattern.FindStringSubmatch(name)
+		kind := map[string]string{"V": "Flyway versioned", "U": "Flyway undo", "R": "Flyway repeatable"}[matches[1]]
+		migration = fmt.Sprintf("%s migration", kind)
+		if matches[2] != "" {
+			migration += " " + strings.ReplaceAll(matches[2], "_", ".")
+		}
+		migration += ": " + humanizeName(matches[3])
+	case timestampPattern.MatchString(name):
+		matches := timestampPattern.FindStringSubmatch(name)
+		migration = fmt.Sprintf("migration %s: %s", matches[1], humanizeName(matches[2]))
+	}
+
+	// Up and down sections marked inside the file, with their statement counts
+	markers := migrationMarkerPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(markers) == 0 {
+		return migration
+	}
+	tool := map[string]string{"+goose": "goose", "+migrate": "sql-migrate", "migrate:": "dbmate"}[strings.TrimSpace(content[markers[0][2]:markers[0][3]])]
+	var sections []string
+	for i, marker := range markers {
+		end := len(content)
+		if i+1 < len(markers) {
+			end = markers[i+1][0]
+		}
+		count := len(splitSQLStatements(content[marker[1]:end]))
+		sections = append(sections, fmt.Sprintf("%s: %d", strings.ToLower(content[marker[4]:marker[5]]), count))
+	}
+	sectionInfo := fmt.Sprintf("%s sections %s", tool, strings.Join(sections, ", "))
+	if migration == "" {
+		return sectionInfo
+	}
+	return migration + " (" + sectionInfo + ")"
+}
+
+// humanizeName turns a snake_case migration name into words
+func humanizeName(name string) string {
+	return strings.NewReplacer("_", " ", "-", " ").Replace(name)
+}
@@ -0,0 +1,43 @@
+package core
+
+import "testing"
+
+func TestSplitSQLStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "doubled quote",
+			content: "INSERT INTO t VALUES ('it''s; y');\nSELECT 1;",
+			want:    []string{"INSERT INTO t VALUES ('it''s; y')", "SELECT 1"},
+		},
+		{
+			name:    "MySQL backslash escape",
+			content: "-- MySQL dump\nINSERT INTO `t` VALUES ('O\\'Brien; x');\nSELECT 1;",
+			want:    []string{"INSERT INTO `t` VALUES ('O\\'Brien; x')", "SELECT 1"},
+		},
+		{
+			name:    "standard string ends at backslash",
+			content: "INSERT INTO t VALUES ('C:\\');\nSELECT 1;",
+			want:    []string{"INSERT INTO t VALUES ('C:\\')", "SELECT 1"},
+		},
+		{
+			name:    "PostgreSQL escape string",
+			content: "INSERT INTO t VALUES (E'it\\'s; x');\nSELECT 1;",
+			want:    []string{"INSERT INTO t VALUES (E'it\\'s; x')", "SELECT 1"},
+		},
+		{
+			name:    "dollar quoted body",
+			content: "CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;\nSELECT 2;",
+			want:    []string{"CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql", "SELECT 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertStrings(t, "statements", splitSQLStatements(tt.content), tt.want)
+		})
+	}
+}
@@ -28,20 +28,53 @@ type FileSummary struct {
 	Types         []string
 	Structs       []string
 	Error         string
+	Warning       string // Problem that did not stop the summary, e.g. a syntax error with a partial parse
+
+	// Additional fields for languages with richer structure
+	Package     string              // Package or namespace declared by the file
+	Interfaces  []string            // Interface types
+	Constants   []string            // Top-level constants
+	Variables   []string            // Top-level variables
+	Members     map[string][]string // Methods grouped by receiver or owning type
+	Annotations []string            // Annotations or attributes used in the file
+	Prototypes  []string            // Function declarations without a body
+	Macros      []string            // Preprocessor macro definitions
+
+	// Additional fields for scripts
+	Shebang  string   // Interpreter line, e.g. #!/usr/bin/env bash
+	Commands []string // External commands invoked, with counts
+	Flags    []string // Command-line flags handled via getopts or case arms
 
 	// Additional fields for non-code files
-	Headers         []string // For markdown headers
-	Links           []string // For markdown links
-	ConfigKeys      []string // For config file keys
-	FileSize        int64    // File size in bytes
-	IsExecutable    bool     // Whether file is executable
-	ExecutableHelp  string   // Help text from executable
-	Content         []string // First few lines for text files
-	RenderedContent string   // Glamour-rendered markdown or formatted content
-	IsRendered      bool     // Whether content has been rendered with glamour
+	Headers         []string          // For markdown headers
+	Links           []string          // For markdown links
+	ConfigKeys      []string          // For config file keys
+	Documents       []DocumentSummary // Per-document keys of multi-document files
+	XML             *XMLSummary       // Structure of XML documents
+	Table           *TableSummary     // Schema and preview of CSV/TSV files
+	Log             *LogSummary       // Format, levels and timeline of log files
+	Docker          *DockerSummary    // Stages and runtime settings of Dockerfiles
+	Tasks           *TaskSummary      // Targets of Makefiles, justfiles and Taskfiles
+	Manifest        *ManifestSummary  // Dependencies and scripts of project manifests
+	Lock            *LockSummary      // Resolved packages of lockfiles
+	SQL             *SQLSummary       // SQL statements, schema objects and migration info
+	HTML            *HTMLSummary      // Head and structure of HTML documents
+	CSS             *CSSSummary       // Rules and at-rules of stylesheets
+	Proto           *ProtoSummary     // Messages, enums and services of .proto files
+	GraphQL         *GraphQLSummary   // Types, root fields and operations of GraphQL documents
+	Terraform       *TerraformSummary // Providers, resources, variables and outputs of Terraform/HCL files
+	Notebook        *NotebookSummary  // Kernel, cells, outputs and errors of Jupyter notebooks
+	FileSize        int64             // File size in bytes
+	IsExecutable    bool              // Whether file is executable
+	Binary          *BinaryInfo       // Static inspection of compiled executables
+	ExecutableHelp  string            // Help text from executable
+	Content         []string          // First few lines for text files
+	RenderedContent string            // Glamour-rendered markdown or formatted content
+	IsRendered      bool              // Whether content has been rendered with glamour
 }
 
-// LanguageConfig holds regex patterns for different programming languages
+// LanguageConfig holds regex patterns for different programming languages.
+// Patterns left nil are skipped.
 type LanguageConfig struct {
 	FunctionPattern *regexp.Regexp
 	ImportPattern   *regexp.Regexp
@@ -49,75 +82,73 @@ type LanguageConfig struct {
 	StructPattern   *regexp.Regexp
 }
 
-// Language patterns for different file types
-var languageConfigs = map[string]LanguageConfig{
-	".go": {
-		FunctionPattern: regexp.MustCompile(`^func\s+(\w+)`),
-		ImportPattern:   regexp.MustCompile(`^import\s+["']([^"']+)["']|^\s+["']([^"']+)["']`),
-		TypePattern:     regexp.MustCompile(`^type\s+(\w+)\s+`),
-		StructPattern:   regexp.MustCompile(`^type\s+(\w+)\s+struct`),
-	},
-	".py": {
-		FunctionPattern: regexp.MustCompile(`^def\s+(\w+)`),
-		ImportPattern:   regexp.MustCompile(`^(?:from\s+(\S+)\s+)?import\s+(.+)`),
-		TypePattern:     regexp.MustCompile(`^class\s+(\w+)`),
-		StructPattern:   regexp.MustCompile(`^class\s+(\w+)`),
-	},
-	".js": {
-		FunctionPattern: regexp.MustCompile(`^(?:function\s+(\w+)|const\s+(\w+)\s*=.*=>|(\w+)\s*:\s*function)`),
-		ImportPattern:   regexp.MustCompile(`^import.*from\s+['"]([^'"]+)['"]|^const\s+.*=\s+require\(['"]([^'"]+)['"]\)`),
-		TypePattern:     regexp.MustCompile(`^(?:class\s+(\w+)|interface\s+(\w+))`),
-		StructPattern:   regexp.MustCompile(`^(?:class\s+(\w+)|interface\s+(\w+))`),
-	},
-	".ts": {
-		FunctionPattern: regexp.MustCompile(`^(?:function\s+(\w+)|const\s+(\w+)\s*=.*=>|(\w+)\s*:\s*function)`),
-		ImportPattern:   regexp.MustCompile(`^import.*from\s+['"]([^'"]+)['"]`),
-		TypePattern:     regexp.MustCompile(`^(?:class\s+(\w+)|interface\s+(\w+)|type\s+(\w+))`),
-		StructPattern:   regexp.MustCompile(`^(?:class\s+(\w+)|interface\s+(\w+))`),
-	},
-	".rs": {
-		FunctionPattern: regexp.MustCompile(`^(?:pub\s+)?fn\s+(\w+)`),
-		ImportPattern:   regexp.MustCompile(`^use\s+([^;]+);`),
-		TypePattern:     regexp.MustCompile(`^(?:pub\s+)?(?:struct\s+(\w+)|enum\s+(\w+)|type\s+(\w+))`),
-		StructPattern:   regexp.MustCompile(`^(?:pub\s+)?struct\s+(\w+)`),
-	},
-	".cpp": {
-		FunctionPattern: regexp.MustCompile(`^(?:[a-zA-Z_][a-zA-Z0-9_]*\s+)?(?:[a-zA-Z_][a-zA-Z0-9_]*::)?\w+\s+(\w+)\s*\(`),
-		ImportPattern:   regexp.MustCompile(`^#include\s+[<"]([^>"]+)[>"]`),
-		TypePattern:     regexp.MustCompile(`^(?:class|struct|enum|union)\s+(\w+)`),
-		StructPattern:   regexp.MustCompile(`^(?:class|struct)\s+(\w+)`),
-	},
-	".cc": {
-		FunctionPattern: regexp.MustCompile(`^(?:[a-zA-Z_][a-zA-Z0-9_]*\s+)?(?:[a-zA-Z_][a-zA-Z0-9_]*::)?\w+\s+(\w+)\s*\(`),
-		ImportPattern:   regexp.MustCompile(`^#include\s+[<"]([^>"]+)[>"]`),
-		TypePattern:     regexp.MustCompile(`^(?:class|struct|enum|union)\s+(\w+)`),
-		StructPattern:   regexp.MustCompile(`^(?:class|struct)\s+(\w+)`),
-	},
-}
-
 // Summarizer handles file analysis and summary generation
 type Summarizer struct {
-	basePath string
+	basePath      string
+	helpAllowlist map[string]bool // Resolved executable paths that may be run with --help
+	xmlDepth      int             // Levels of XML element trees to summarize
 }
 
 // NewSummarizer creates a new file summarizer
 func NewSummarizer(basePath string) *Summarizer {
-	return &Summarizer{basePath: basePath}
+	return &Summarizer{basePath: basePath, helpAllowlist: make(map[string]bool), xmlDepth: DefaultXMLDepth}
+}
+
+// AllowHelp lets the executables at the given paths be run for their help
+// text whenever they are summarized. Paths are resolved to absolute paths with
+// symlinks followed, so an executable that merely shares a name with an
+// allowlisted one is still only inspected statically.
+func (s *Summarizer) AllowHelp(paths ...string) {
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			if resolved, ok := resolveExecutablePath(path); ok {
+				s.helpAllowlist[resolved] = true
+			}
+		}
+	}
+}
+
+// resolveExecutablePath returns the absolute, symlink-free form of a path
+func resolveExecutablePath(path string) (string, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	resolved, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return "", false
+	}
+	return resolved, true
+}
+
+// ExecutableHelp runs an executable with --help and returns its
+// output. It is only called on explicit user request or for allowlisted names.
+func (s *Summarizer) ExecutableHelp(filePath string) string {
+	return s.getExecutableHelp(filepath.Join(s.basePath, filePath))
 }
 
 // SummarizeFile analyzes a file and returns its summary
 func (s *Summarizer) SummarizeFile(filePath string) FileSummary {
 	summary := FileSummary{
-		Path:       filePath,
-		Language:   getLanguage(filePath),
-		Functions:  make([]string, 0),
-		Imports:    make([]string, 0),
-		Types:      make([]string, 0),
-		Structs:    make([]string, 0),
-		Headers:    make([]string, 0),
-		Links:      make([]string, 0),
-		ConfigKeys: make([]string, 0),
-		Content:    make([]string, 0),
+		Path:        filePath,
+		Language:    getLanguage(filePath),
+		Functions:   make([]string, 0),
+		Imports:     make([]string, 0),
+		Types:       make([]string, 0),
+		Structs:     make([]string, 0),
+		Interfaces:  make([]string, 0),
+		Constants:   make([]string, 0),
+		Variables:   make([]string, 0),
+		Members:     make(map[string][]string),
+		Annotations: make([]string, 0),
+		Prototypes:  make([]string, 0),
+		Macros:      make([]string, 0),
+		Commands:    make([]string, 0),
+		Flags:       make([]string, 0),
+		Headers:     make([]string, 0),
+		Links:       make([]string, 0),
+		ConfigKeys:  make([]string, 0),
+		Content:     make([]string, 0),
 	}
 
 	// Construct full path
@@ -131,61 +162,36 @@ func (s *Summarizer) SummarizeFile(filePath string) FileSummary {
 	}
 	summary.FileSize = fileInfo.Size()
 
-	// Check if file is executable
+	// Dispatch to the registered parser, treating unknown files as text.
+	// Scripts and binaries are analyzed statically, never executed.
 	summary.IsExecutable = utils.IsExecutableFile(fullPath)
-	if summary.IsExecutable {
-		summary.ExecutableHelp = s.getExecutableHelp(fullPath)
-		return summary
+	spec, found := findParser(fullPath)
+	if !found {
+		summary = s.parseTextFile(fullPath, summary)
+	} else {
+		if summary.Language == "Unknown" {
+			summary.Language = spec.Language
+		}
+		summary = spec.Parser.Parse(s, fullPath, summary)
 	}
 
-	// Get file extension and determine parsing strategy
-	ext := strings.ToLower(filepath.Ext(filePath))
-
-	// Handle different file types
-	switch ext {
-	case ".md", ".markdown":
-		return s.parseMarkdown(fullPath, summary)
-	case ".json":
-		return s.parseJSON(fullPath, summary)
-	case ".yaml", ".yml":
-		return s.parseYAML(fullPath, summary)
-	case ".ini", ".cfg", ".conf":
-		return s.parseINI(fullPath, summary)
-	case ".env":
-		return s.parseEnv(fullPath, summary)
-	case ".txt", ".log", ".rst", ".xml", ".csv":
-		return s.parseTextFile(fullPath, summary)
-	default:
-		// Try to parse as source code using language-specific parsers
-		switch ext {
-		case ".go":
-			return s.parseGoFile(fullPath, summary)
-		case ".py":
-			return s.parsePythonFile(fullPath, summary)
-		case ".js", ".jsx", ".ts", ".tsx":
-			return s.parseJavaScriptFile(fullPath, summary)
-		case ".rs":
-			return s.parseRustFile(fullPath, summary)
-		case ".cpp", ".cc":
-			return s.parseCppFile(fullPath, summary)
-		default:
-			// Try to parse as source code using generic parser
-			config, exists := languageConfigs[ext]
-			if !exists {
-				// If not a known source file, treat as text
-				return s.parseTextFile(fullPath, summary)
-			}
-			return s.parseSourceCode(fullPath, summary, config)
+	// Only allowlisted executables are run for their help text
+	if summary.IsExecutable && len(s.helpAllowlist) > 0 {
+		if resolved, ok := resolveExecutablePath(fullPath); ok && s.helpAllowlist[resolved] {
+			summary.ExecutableHelp = s.getExecutableHelp(fullPath)
 		}
 	}
+	return summary
 }
 
-// parseSourceCode handles traditional programming language files
-func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, config LanguageConfig) FileSummary {
-	// TODO: Refactor into language-specific parsing functions
-	// This is a temporary placeholder while we implement the refactoring
-	// The current implementation will be replaced with language-specific parsers
+// CanSummarize reports whether a registered parser handles the file
+func (s *Summarizer) CanSummarize(filePath string) bool {
+	_, found := findParser(filepath.Join(s.basePath, filePath))
+	return found
+}
 
+// parseSourceCode handles programming language files described by a LanguageConfig
+func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, config LanguageConfig) FileSummary {
 	file, err := os.Open(fullPath)
 	if err != nil {
 		summary.Error = fmt.Sprintf("Error opening file: %v", err)
@@ -219,7 +225,7 @@ func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, confi
 		}
 
 		// Extract functions
-		if matches := config.FunctionPattern.FindStringSubmatch(line); matches != nil {
+		if matches := findSubmatch(config.FunctionPattern, line); matches != nil {
 			for _, match := range matches[1:] {
 				if match != "" {
 					summary.Functions = append(summary.Functions, match)
@@ -230,7 +236,7 @@ func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, confi
 		}
 
 		// Extract imports
-		if matches := config.ImportPattern.FindStringSubmatch(line); matches != nil {
+		if matches := findSubmatch(config.ImportPattern, line); matches != nil {
 			for _, match := range matches[1:] {
 				if match != "" {
 					summary.Imports = append(summary.Imports, match)
@@ -240,7 +246,7 @@ func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, confi
 		}
 
 		// Extract types
-		if matches := config.TypePattern.FindStringSubmatch(line); matches != nil {
+		if matches := findSubmatch(config.TypePattern, line); matches != nil {
 			for _, match := range matches[1:] {
 				if match != "" {
 					summary.Types = append(summary.Types, match)
@@ -250,7 +256,7 @@ func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, confi
 		}
 
 		// Extract structs
-		if matches := config.StructPattern.FindStringSubmatch(line); matches != nil {
+		if matches := findSubmatch(config.StructPattern, line); matches != nil {
 			for _, match := range matches[1:] {
 				if match != "" {
 					summary.Structs = append(summary.Structs, match)
@@ -269,6 +275,14 @@ func (s *Summarizer) parseSourceCode(fullPath string, summary FileSummary, confi
 	return summary
 }
 
+// findSubmatch is FindStringSubmatch that tolerates a nil pattern
+func findSubmatch(pattern *regexp.Regexp, line string) []string {
+	if pattern == nil {
+		return nil
+	}
+	return pattern.FindStringSubmatch(line)
+}
+
 // parseMarkdown extracts headers, links, and structure from markdown files
 func (s *Summarizer) parseMarkdown(fullPath string, summary FileSummary) FileSummary {
 	// Read the entire markdown file
@@ -423,47 +437,39 @@ Keyboard Controls:
   Home/End      Jump to first/last file
   t             Toggle directory visibility
   r             Refresh current directory
+  x             Run selected executable with --help
   q or Ctrl+C   Quit`
 	}
 
-	// Try common help flags for other executables
-	helpFlags := []string{"--help", "-h", "help", "/?"}
+	// Only --help is tried: other spellings such as a bare "help" argument
+	// can be real subcommands of an unknown binary
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	cmd := exec.CommandContext(ctx, fullPath, "--help")
+	output, err := cmd.CombinedOutput()
+	cancel()
 
-	for _, flag := range helpFlags {
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-
-		cmd := exec.CommandContext(ctx, fullPath, flag)
-		output, err := cmd.CombinedOutput()
-
-		if err == nil && len(output) > 0 {
-			// Limit output to first 25 lines
-			lines := strings.Split(string(output), "\n")
-			if len(lines) > 25 {
-				lines = lines[:25]
-				lines = append(lines, "... (truncated)")
-			}
-			return strings.Join(lines, "\n")
+	if err == nil && len(output) > 0 {
+		// Limit output to first 25 lines
+		lines := strings.Split(string(output), "\n")
+		if len(lines) > 25 {
+			lines = lines[:25]
+			lines = append(lines, "... (truncated)")
 		}
+		return strings.Join(lines, "\n")
 	}
 
-	// If no help flags work, show basic executable info
+	// If --help does not work, show basic executable info
 	fileInfo, err := os.Stat(fullPath)
 	sizeStr := "unknown"
 	if err == nil {
 		sizeStr = formatBytes(fileInfo.Size())
 	}
 
-	return fmt.Sprintf("Executable: %s\n\nThis is an executable file.\nHelp flags (--help, -h, help, /?) did not produce output.\n\nFile size: %s\nType: Binary executable",
+	return fmt.Sprintf("Executable: %s\n\nThis is an executable file.\n--help did not produce output.\n\nFile size: %s\nType: Binary executable",
 		fileName,
 		sizeStr)
 }
 
-func (s *Summarizer) parseYAML(fullPath string, summary FileSummary) FileSummary {
-	// For now, treat as text file
-	return s.parseTextFile(fullPath, summary)
-}
-
 func (s *Summarizer) parseINI(fullPath string, summary FileSummary) FileSummary {
 	content, err := os.ReadFile(fullPath)
 	if err != nil {
@@ -576,71 +582,6 @@ func extractJSONKeys(data interface{}, prefix string) []string {
 	return keys
 }
 
-// parseGoFile handles Go-specific parsing with proper comment handling
-func (s *Summarizer) parseGoFile(fullPath string, summary FileSummary) FileSummary {
-	file, err := os.Open(fullPath)
-	if err != nil {
-		summary.Error = fmt.Sprintf("Error opening file: %v", err)
-		return summary
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	lineCount := 0
-	inMultiLineComment := false
-
-	for scanner.Scan() {
-		lineCount++
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip empty lines and single-line comments
-		if line == "" || strings.HasPrefix(line, "//") {
-			continue
-		}
-
-		// Handle multi-line comments
-		if strings.Contains(line, "/*") {
-			inMultiLineComment = true
-		}
-		if inMultiLineComment {
-			if strings.Contains(line, "*/") {
-				inMultiLineComment = false
-			}
-			continue
-		}
-
-		// Extract Go-specific patterns
-		if matches := regexp.MustCompile(`^func\s+(\w+)`).FindStringSubmatch(line); matches != nil {
-			summary.Functions = append(summary.Functions, matches[1])
-			summary.FunctionCount++
-		}
-
-		if matches := regexp.MustCompile(`^import\s+["']([^"']+)["']|^\s+["']([^"']+)["']`).FindStringSubmatch(line); matches != nil {
-			for _, match := range matches[1:] {
-				if match != "" {
-					summary.Imports = append(summary.Imports, match)
-					break
-				}
-			}
-		}
-
-		if matches := regexp.MustCompile(`^type\s+(\w+)\s+`).FindStringSubmatch(line); matches != nil {
-			summary.Types = append(summary.Types, matches[1])
-		}
-
-		if matches := regexp.MustCompile(`^type\s+(\w+)\s+struct`).FindStringSubmatch(line); matches != nil {
-			summary.Structs = append(summary.Structs, matches[1])
-		}
-	}
-
-	summary.LineCount = lineCount
-	if err := scanner.Err(); err != nil {
-		summary.Error = fmt.Sprintf("Error reading file: %v", err)
-	}
-
-	return summary
-}
-
 // parsePythonFile handles Python-specific parsing
 func (s *Summarizer) parsePythonFile(fullPath string, summary FileSummary) FileSummary {
 	file, err := os.Open(fullPath)
@@ -917,66 +858,10 @@ func formatBytes(size int64) string {
 	return fmt.Sprintf("%d B", size)
 }
 
-// getLanguage determines the programming language based on file extension
+// getLanguage determines the language from the parser registered for the file
 func getLanguage(filePath string) string {
-	ext := strings.ToLower(filepath.Ext(filePath))
-
-	languageMap := map[string]string{
-		// Programming languages
-		".go":    "Go",
-		".py":    "Python",
-		".js":    "JavaScript",
-		".ts":    "TypeScript",
-		".jsx":   "React/JSX",
-		".tsx":   "React/TSX",
-		".rs":    "Rust",
-		".java":  "Java",
-		".c":     "C",
-		".cpp":   "C++",
-		".cc":    "C++",
-		".h":     "C Header",
-		".hpp":   "C++ Header",
-		".cs":    "C#",
-		".php":   "PHP",
-		".rb":    "Ruby",
-		".swift": "Swift",
-		".kt":    "Kotlin",
-		".scala": "Scala",
-
-		// Markup and documentation
-		".md":       "Markdown",
-		".markdown": "Markdown",
-		".txt":      "Text",
-		".rst":      "reStructuredText",
-
-		// Configuration files
-		".json":       "JSON",
-		".yaml":       "YAML",
-		".yml":        "YAML",
-		".toml":       "TOML",
-		".ini":        "INI",
-		".cfg":        "Config",
-		".conf":       "Config",
-		".env":        "Environment",
-		".properties": "Properties",
-
-		// Data files
-		".xml": "XML",
-		".csv": "CSV",
-		".log": "Log",
-
-		// Shell and scripts
-		".sh":   "Shell",
-		".bash": "Bash",
-		".zsh":  "Zsh",
-		".fish": "Fish",
-		".ps1":  "PowerShell",
-		".bat":  "Batch",
-		".cmd":  "Command",
-	}
-
-	if lang, exists := languageMap[ext]; exists {
-		return lang
+	if spec, found := LookupParser(filePath); found {
+		return spec.Language
 	}
 	return "Unknown"
 }
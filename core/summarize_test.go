@@ -0,0 +1,83 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// summarizeContent writes content to a file with the given name in a
+// temporary directory and summarizes it
+func summarizeContent(t *testing.T, name, content string) FileSummary {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return NewSummarizer(dir).SummarizeFile(name)
+}
+
+// assertStrings fails the test when got and want differ, treating nil and
+// empty slices as equal
+func assertStrings(t *testing.T, label string, got, want []string) {
+	t.Helper()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %q, want %q", label, got, want)
+	}
+}
+
+func TestParseGoFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		functions []string
+		error     string
+		warning   string
+	}{
+		{
+			name:      "valid",
+			content:   "package x\n\nfunc F() {}\nfunc g() {}\n",
+			functions: []string{"+ F", "- g"},
+		},
+		{
+			name:      "syntax error keeps partial AST",
+			content:   "package x\nfunc F() {\n",
+			functions: []string{"+ F"},
+			warning:   "Syntax error",
+		},
+		{
+			name:    "invalid first token",
+			content: "@@@ not go",
+			error:   "Error parsing Go file",
+		},
+		{
+			name:    "unterminated leading comment",
+			content: "/* never closed",
+			error:   "Error parsing Go file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizeContent(t, "main.go", tt.content)
+			if !strings.HasPrefix(summary.Error, tt.error) || (tt.error == "" && summary.Error != "") {
+				t.Errorf("Error = %q, want prefix %q", summary.Error, tt.error)
+			}
+			if !strings.HasPrefix(summary.Warning, tt.warning) || (tt.warning == "" && summary.Warning != "") {
+				t.Errorf("Warning = %q, want prefix %q", summary.Warning, tt.warning)
+			}
+			if tt.error == "" {
+				assertStrings(t, "Functions", summary.Functions, tt.functions)
+			}
+		})
+	}
+}
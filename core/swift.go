@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// swiftModifiers is the alternation of Swift declaration modifiers
+const swiftModifiers = `public|open|internal|fileprivate|private|static|class|final|override|mutating|nonmutating|convenience|required|indirect|lazy|weak|unowned|dynamic|nonisolated|async|distributed|(?:private|fileprivate|internal|public)\(set\)`
+
+// Swift declaration patterns, applied to comment-stripped lines
+var (
+	swiftImportPattern    = regexp.MustCompile(`^(?:@\w+\s+)?import\s+(?:(?:typealias|struct|class|enum|protocol|let|var|func)\s+)?([\w.]+)`)
+	swiftAttributePattern = regexp.MustCompile(`^@(\w+)(?:\([^)]*\))?\s*`)
+	swiftTypePattern      = regexp.MustCompile(`^((?:(?:` + swiftModifiers + `)\s+)*)(struct|class|enum|protocol|extension|actor)\s+([\w.]+)`)
+	swiftFunctionPattern  = regexp.MustCompile(`^((?:(?:` + swiftModifiers + `)\s+)*)(?:func\s+([\w]+|[^\s(<\w]+)|(init|deinit|subscript)\b[?!]?)`)
+
+	// swiftClassMemberPattern matches the word after "class" when it is used
+	// as a modifier, as in "class func" or "override class var"
+	swiftClassMemberPattern = regexp.MustCompile(`^(?:` + swiftModifiers + `|func|var|let|subscript|init|deinit|typealias)$`)
+)
+
+// parseSwiftFile extracts imports, structs, classes, enums, protocols,
+// extensions and functions from Swift source
+func (s *Summarizer) parseSwiftFile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	lines := strings.Split(stripCComments(string(content)), "\n")
+	summary.LineCount = len(lines)
+
+	var scopes scopeStack
+	attributeCounts := make(map[string]int)
+	var attributeOrder []string
+	depth := 0
+
+	// addFunction records a method of the enclosing type or a top-level function
+	addFunction := func(line string, lineDepth int) {
+		matches := swiftFunctionPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return
+		}
+		name := matches[2] + matches[3]
+		if owner, ok := scopes.current(); ok && owner.opened && lineDepth == owner.depth+1 {
+			// Protocol requirements are as visible as the protocol itself
+			fallback := "~ "
+			if owner.kind == "protocol" {
+				fallback = "+ "
+			}
+			summary.Members[owner.name] = append(summary.Members[owner.name], accessMarker(matches[1], fallback)+name)
+			summary.FunctionCount++
+		} else if !ok && lineDepth == 0 && matches[2] != "" {
+			summary.Functions = append(summary.Functions, accessMarker(matches[1], "~ ")+name)
+			summary.FunctionCount++
+		}
+	}
+
+	for i, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
+		lineDepth := depth
+		depth += braceDelta(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if matches := swiftImportPattern.FindStringSubmatch(line); matches != nil {
+			summary.Imports = append(summary.Imports, matches[1])
+			continue
+		}
+
+		for {
+			matches := swiftAttributePattern.FindStringSubmatch(line)
+			if matches == nil {
+				break
+			}
+			name := "@" + matches[1]
+			if attributeCounts[name] == 0 {
+				attributeOrder = append(attributeOrder, name)
+			}
+			attributeCounts[name]++
+			line = line[len(matches[0]):]
+		}
+
+		if matches := swiftTypePattern.FindStringSubmatch(line); matches != nil && !(matches[2] == "class" && swiftClassMemberPattern.MatchString(matches[3])) {
+			kind := matches[2]
+			name := matches[3]
+			if kind != "extension" {
+				name = scopes.qualifiedName(name)
+			}
+			entry := accessMarker(matches[1], "~ ") + name
+
+			summary.Types = append(summary.Types, fmt.Sprintf("%s (%s)", entry, kind))
+			switch kind {
+			case "struct", "class", "actor":
+				summary.Structs = append(summary.Structs, entry)
+			case "protocol":
+				summary.Interfaces = append(summary.Interfaces, entry)
+			}
+
+			if declarationHasBody(lines, i) {
+				scopes = scopes.push(name, kind, lineDepth, strings.Contains(line, "{"))
+				// Members of a body written on the declaration line
+				for _, member := range bodyMembers(line) {
+					addFunction(member, lineDepth+1)
+				}
+			}
+			scopes = scopes.update(depth)
+			continue
+		}
+
+		addFunction(line, lineDepth)
+		scopes = scopes.update(depth)
+	}
+
+	summary.Annotations = formatCounts(attributeOrder, attributeCounts)
+	return summary
+}
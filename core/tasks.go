@@ -0,0 +1,381 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TaskSummary describes the targets of a Makefile, justfile or Taskfile
+type TaskSummary struct {
+	Runner  string // make, just or task
+	Default string // Target run when none is named
+	Targets []TaskTarget
+}
+
+// TaskTarget is one make target, just recipe or Taskfile task
+type TaskTarget struct {
+	Name          string
+	Prerequisites []string // Targets that run first
+	Help          string   // "##" help comment, doc comment or desc
+	Phony         bool     // Declared .PHONY, i.e. not a file
+}
+
+// justParameter matches a recipe parameter such as +args or name="default",
+// where quoted and backquoted parts may hold any character
+const justParameter = `(?:[^\s:"'` + "`" + `]|"(?:[^"\\]|\\.)*"|'[^']*'|` + "`[^`]*`" + `)+`
+
+var (
+	// makeAssignmentPattern matches variable assignments with any of make's operators
+	makeAssignmentPattern = regexp.MustCompile(`^(?:(?:export|override|private)\s+)*([A-Za-z_][A-Za-z0-9_.-]*)\s*(?:::=|:::=|:=|\?=|\+=|!=|=)`)
+	// makeRulePattern matches "targets: prerequisites" and "targets:: prerequisites"
+	makeRulePattern = regexp.MustCompile(`^([^:=#]+?)\s*::?(.*)$`)
+	// makeIncludePattern matches include, -include and sinclude directives
+	makeIncludePattern = regexp.MustCompile(`^(?:-include|sinclude|include)\s+(.+)$`)
+	// justRecipePattern matches a recipe header: name, parameters, ':' and
+	// dependencies. Parameter defaults may be quoted and contain ':' or spaces.
+	justRecipePattern = regexp.MustCompile(`^@?([A-Za-z_][A-Za-z0-9_-]*)((?:\s+` + justParameter + `)*)\s*:(?:\s+(.*))?$`)
+	// justParameterPattern matches one parameter of a recipe header
+	justParameterPattern = regexp.MustCompile(justParameter)
+	// justAssignmentPattern matches "name := value" and "export name := value"
+	justAssignmentPattern = regexp.MustCompile(`^(?:export\s+)?([A-Za-z_][A-Za-z0-9_-]*)\s*:=`)
+	// justImportPattern matches import and mod statements
+	justImportPattern = regexp.MustCompile(`^(?:import\??\s+(.+)|mod\??\s+([A-Za-z_][A-Za-z0-9_-]*)(?:\s+(.+))?)$`)
+)
+
+// parseMakefile lists targets with their prerequisites and "##" help comments,
+// .PHONY declarations, variables and included makefiles
+func (s *Summarizer) parseMakefile(fullPath string, summary FileSummary) FileSummary {
+	file, err := os.Open(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error opening file: %v", err)
+		return summary
+	}
+	defer file.Close()
+
+	tasks := &TaskSummary{Runner: "make"}
+	targets := make(map[string]int) // Index into tasks.Targets
+	phony := make(map[string]bool)
+	var help string // "##" comment on the line above a target
+	var statement strings.Builder
+	inDefine := false
+	lineCount := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineCount++
+		line := scanner.Text()
+
+		// Recipe lines belong to the previous rule
+		if statement.Len() == 0 && strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		// Join continuation lines
+		if strings.HasSuffix(line, `\`) {
+			statement.WriteString(strings.TrimSuffix(line, `\`) + " ")
+			continue
+		}
+		statement.WriteString(line)
+		line = strings.TrimSpace(statement.String())
+		statement.Reset()
+
+		if inDefine {
+			if strings.HasPrefix(line, "endef") {
+				inDefine = false
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "##") {
+			help = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			help = ""
+			continue
+		}
+
+		// Split off a trailing "## help" comment, then any other comment
+		inlineHelp := ""
+		if index := strings.Index(line, "##"); index >= 0 {
+			inlineHelp = strings.TrimSpace(strings.TrimLeft(line[index:], "#"))
+			line = strings.TrimSpace(line[:index])
+		} else if index := strings.Index(line, "#"); index >= 0 {
+			line = strings.TrimSpace(line[:index])
+		}
+
+		fields := strings.Fields(line)
+		switch {
+		case fields[0] == "define" || (len(fields) > 1 && fields[1] == "define" && (fields[0] == "export" || fields[0] == "override")):
+			inDefine = true
+			name := fields[len(fields)-1]
+			if fields[0] == "define" && len(fields) > 1 {
+				name = fields[1]
+			} else if len(fields) > 2 {
+				name = fields[2]
+			}
+			summary.Variables = append(summary.Variables, name)
+
+		case makeIncludePattern.MatchString(line):
+			matches := makeIncludePattern.FindStringSubmatch(line)
+			summary.Imports = append(summary.Imports, strings.Fields(matches[1])...)
+
+		case fields[0] == "ifeq" || fields[0] == "ifneq" || fields[0] == "ifdef" || fields[0] == "ifndef" ||
+			fields[0] == "else" || fields[0] == "endif" || fields[0] == "vpath" || fields[0] == "unexport":
+			// Conditionals and directives are not targets
+
+		case makeAssignmentPattern.MatchString(line):
+			name := makeAssignmentPattern.FindStringSubmatch(line)[1]
+			if name == ".DEFAULT_GOAL" {
+				_, value, _ := strings.Cut(line, "=")
+				tasks.Default = strings.TrimSpace(value)
+				break
+			}
+			summary.Variables = append(summary.Variables, name)
+
+		case makeRulePattern.MatchString(line):
+			matches := makeRulePattern.FindStringSubmatch(line)
+			names := strings.Fields(matches[1])
+			// An inline recipe follows ";"
+			rest, _, _ := strings.Cut(matches[2], ";")
+			// Target-specific variables are not prerequisites
+			if makeAssignmentPattern.MatchString(strings.TrimSpace(rest)) {
+				break
+			}
+			prerequisites := strings.Fields(strings.ReplaceAll(rest, "|", " "))
+
+			if len(names) == 1 && names[0] == ".PHONY" {
+				for _, name := range prerequisites {
+					phony[name] = true
+				}
+				break
+			}
+			for _, name := range names {
+				if strings.HasPrefix(name, ".") && !strings.ContainsAny(name, "/%") {
+					continue // Special targets such as .SUFFIXES
+				}
+				if index, exists := targets[name]; exists {
+					target := &tasks.Targets[index]
+					target.Prerequisites = append(target.Prerequisites, prerequisites...)
+					if target.Help == "" {
+						target.Help = firstNonEmpty(inlineHelp, help)
+					}
+					continue
+				}
+				targets[name] = len(tasks.Targets)
+				tasks.Targets = append(tasks.Targets, TaskTarget{
+					Name:          name,
+					Prerequisites: prerequisites,
+					Help:          firstNonEmpty(inlineHelp, help),
+				})
+				// The first ordinary target is the default goal
+				if tasks.Default == "" && !strings.Contains(name, "%") {
+					tasks.Default = name
+				}
+			}
+		}
+		help = ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	for i := range tasks.Targets {
+		tasks.Targets[i].Phony = phony[tasks.Targets[i].Name]
+		tasks.Targets[i].Prerequisites = uniqueStrings(tasks.Targets[i].Prerequisites)
+	}
+	summary.Variables = uniqueStrings(summary.Variables)
+	summary.LineCount = lineCount
+	summary.Tasks = tasks
+	return summary
+}
+
+// parseJustfile lists just recipes with their dependencies and doc comments,
+// variables and imported justfiles or modules
+func (s *Summarizer) parseJustfile(fullPath string, summary FileSummary) FileSummary {
+	file, err := os.Open(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error opening file: %v", err)
+		return summary
+	}
+	defer file.Close()
+
+	tasks := &TaskSummary{Runner: "just"}
+	var comment string // Doc comment on the line above a recipe
+	var defaultAttribute bool
+	lineCount := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineCount++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		// Recipe bodies are indented
+		if line != "" && (raw[0] == ' ' || raw[0] == '\t') {
+			continue
+		}
+		if line == "" {
+			comment = ""
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if !strings.HasPrefix(line, "#!") {
+				comment = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			}
+			continue
+		}
+
+		// Attributes such as [private], [group('ci')] or [doc('...')]
+		if strings.HasPrefix(line, "[") {
+			for _, attribute := range strings.Split(strings.Trim(line, "[]"), ",") {
+				if strings.TrimSpace(attribute) == "default" {
+					defaultAttribute = true
+				}
+			}
+			if _, doc, found := strings.Cut(line, "doc("); found {
+				comment = strings.Trim(strings.TrimSuffix(strings.TrimSuffix(doc, "]"), ")"), `"'`)
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "set ") || strings.HasPrefix(line, "alias "):
+			// Settings and aliases are not recipes
+
+		case justImportPattern.MatchString(line):
+			matches := justImportPattern.FindStringSubmatch(line)
+			if matches[1] != "" {
+				summary.Imports = append(summary.Imports, strings.Trim(matches[1], `"'`))
+			} else if matches[3] != "" {
+				summary.Imports = append(summary.Imports, fmt.Sprintf("%s (%s)", matches[2], strings.Trim(matches[3], `"'`)))
+			} else {
+				summary.Imports = append(summary.Imports, matches[2])
+			}
+
+		case justAssignmentPattern.MatchString(line):
+			summary.Variables = append(summary.Variables, justAssignmentPattern.FindStringSubmatch(line)[1])
+
+		case justRecipePattern.MatchString(line):
+			matches := justRecipePattern.FindStringSubmatch(line)
+			name := matches[1]
+			if parameters := justParameterPattern.FindAllString(matches[2], -1); len(parameters) > 0 {
+				name += " " + strings.Join(parameters, " ")
+			}
+			var dependencies []string
+			for _, dependency := range strings.Fields(matches[3]) {
+				// Dependencies with arguments are written (name arg)
+				dependency = strings.Trim(dependency, "()&")
+				if dependency != "" && !strings.ContainsAny(dependency, `"'`) {
+					dependencies = append(dependencies, dependency)
+				}
+			}
+			tasks.Targets = append(tasks.Targets, TaskTarget{
+				Name:          name,
+				Prerequisites: dependencies,
+				Help:          comment,
+			})
+			if tasks.Default == "" || defaultAttribute {
+				tasks.Default = matches[1]
+			}
+			defaultAttribute = false
+		}
+		comment = ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+
+	summary.LineCount = lineCount
+	summary.Tasks = tasks
+	return summary
+}
+
+// parseTaskfile lists the tasks of a Taskfile.yml with their dependencies and
+// descriptions, along with variables and included Taskfiles
+func (s *Summarizer) parseTaskfile(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		summary.Error = fmt.Sprintf("Invalid YAML: %s", yamlErrorMessage(err, content))
+		return summary
+	}
+	if len(document.Content) == 0 {
+		summary.Tasks = &TaskSummary{Runner: "task"}
+		return summary
+	}
+	root := document.Content[0]
+
+	tasks := &TaskSummary{Runner: "task"}
+	for _, section := range []string{"vars", "env"} {
+		if node := yamlLookup(root, section); node != nil && node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				summary.Variables = append(summary.Variables, node.Content[i].Value)
+			}
+		}
+	}
+
+	if node := yamlLookup(root, "includes"); node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, include := node.Content[i].Value, node.Content[i+1]
+			path := yamlScalar(include)
+			if path == "" {
+				path = yamlScalar(yamlLookup(include, "taskfile"))
+			}
+			summary.Imports = append(summary.Imports, fmt.Sprintf("%s (%s)", name, path))
+		}
+	}
+
+	if node := yamlLookup(root, "tasks"); node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, task := node.Content[i].Value, node.Content[i+1]
+			target := TaskTarget{
+				Name: name,
+				Help: firstNonEmpty(yamlScalar(yamlLookup(task, "desc")), yamlScalar(yamlLookup(task, "summary"))),
+			}
+			if deps := yamlLookup(task, "deps"); deps != nil && deps.Kind == yaml.SequenceNode {
+				for _, dependency := range deps.Content {
+					// Dependencies are either names or {task: name, vars: ...}
+					if dependency.Kind == yaml.ScalarNode {
+						target.Prerequisites = append(target.Prerequisites, dependency.Value)
+					} else if task := yamlScalar(yamlLookup(dependency, "task")); task != "" {
+						target.Prerequisites = append(target.Prerequisites, task)
+					}
+				}
+			}
+			tasks.Targets = append(tasks.Targets, target)
+			if name == "default" {
+				tasks.Default = name
+			}
+		}
+	}
+
+	summary.Tasks = tasks
+	return summary
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJustfileRecipes(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		targets     []TaskTarget
+		defaultName string
+	}{
+		{
+			name:        "colon in quoted default",
+			content:     "serve addr=\":8080\":\n    run {{addr}}\n",
+			targets:     []TaskTarget{{Name: `serve addr=":8080"`}},
+			defaultName: "serve",
+		},
+		{
+			name:        "colon in default with dependency",
+			content:     "build target=\"x:y\": dep\n    make\n\ndep:\n    echo\n",
+			targets:     []TaskTarget{{Name: `build target="x:y"`, Prerequisites: []string{"dep"}}, {Name: "dep"}},
+			defaultName: "build",
+		},
+		{
+			name:        "spaces in single-quoted default",
+			content:     "# Run tests\n@test mode='a b' +args=\"\": build\n    go test\n",
+			targets:     []TaskTarget{{Name: `test mode='a b' +args=""`, Prerequisites: []string{"build"}, Help: "Run tests"}},
+			defaultName: "test",
+		},
+		{
+			name:        "assignment is not a recipe",
+			content:     "version := \"1.0\"\n\nrelease:\n    echo\n",
+			targets:     []TaskTarget{{Name: "release"}},
+			defaultName: "release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizeContent(t, "justfile", tt.content)
+			if summary.Tasks == nil {
+				t.Fatalf("no tasks, Error = %q", summary.Error)
+			}
+			if !reflect.DeepEqual(summary.Tasks.Targets, tt.targets) {
+				t.Errorf("Targets = %+v, want %+v", summary.Tasks.Targets, tt.targets)
+			}
+			if summary.Tasks.Default != tt.defaultName {
+				t.Errorf("Default = %q, want %q", summary.Tasks.Default, tt.defaultName)
+			}
+		})
+	}
+}
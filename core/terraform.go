@@ -0,0 +1,358 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TerraformSummary describes the blocks of a Terraform or HCL file, or of all
+// .tf files of a module directory
+type TerraformSummary struct {
+	Files           []string     // Files of a module directory with what each defines
+	RequiredVersion string       // terraform.required_version
+	Backend         string       // Backend or Terraform Cloud configuration
+	Providers       []string     // Required and configured providers
+	Resources       []Definition // Resource names grouped by type
+	DataSources     []string     // Data sources as type.name
+	Modules         []string     // Module calls with their sources and versions
+	Variables       []string     // Input variables with types and defaults
+	Outputs         []string     // Outputs with their value expressions
+	Locals          []string     // Local value names
+	Blocks          []string     // Other top-level blocks, such as Packer sources or Nomad jobs
+}
+
+// maxHCLExpression bounds the length of expressions shown for defaults and outputs
+const maxHCLExpression = 60
+
+// terraformModule accumulates the blocks of one or more files
+type terraformModule struct {
+	summary    *TerraformSummary
+	resources  map[string]int // Index into summary.Resources by type
+	providers  map[string]int // Index into summary.Providers by name
+	attributes []string       // Top-level attributes, as in .tfvars and terragrunt.hcl
+}
+
+// parseTerraform extracts providers, resources, data sources, modules,
+// variables and outputs from a Terraform or HCL file
+func (s *Summarizer) parseTerraform(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	module := newTerraformModule()
+	if _, err := module.addFile(filepath.Base(fullPath), content); err != nil {
+		summary.Error = fmt.Sprintf("Invalid HCL: %v", err)
+		return summary
+	}
+	summary.ConfigKeys = append(summary.ConfigKeys, module.attributes...)
+	summary.Terraform = module.summary
+	return summary
+}
+
+// SummarizeDirectory returns a combined summary of a directory whose files
+// together form one unit, such as the .tf files of a Terraform module. The
+// second result is false for other directories.
+func (s *Summarizer) SummarizeDirectory(dirPath string) (FileSummary, bool) {
+	summary := FileSummary{Path: dirPath, Language: "Terraform module"}
+	fullPath := filepath.Join(s.basePath, dirPath)
+	files, err := filepath.Glob(filepath.Join(fullPath, "*.tf"))
+	if err != nil || len(files) == 0 {
+		return summary, false
+	}
+	sort.Strings(files)
+
+	module := newTerraformModule()
+	for _, file := range files {
+		name := filepath.Base(file)
+		content, err := os.ReadFile(file)
+		if err != nil {
+			module.summary.Files = append(module.summary.Files, fmt.Sprintf("%s (error: %v)", name, err))
+			continue
+		}
+		summary.LineCount += strings.Count(string(content), "\n") + 1
+		summary.FileSize += int64(len(content))
+
+		defined, err := module.addFile(name, content)
+		switch {
+		case err != nil:
+			module.summary.Files = append(module.summary.Files, fmt.Sprintf("%s (invalid: %v)", name, err))
+		case len(defined) > 0:
+			module.summary.Files = append(module.summary.Files, fmt.Sprintf("%s: %s", name, strings.Join(defined, ", ")))
+		default:
+			module.summary.Files = append(module.summary.Files, name)
+		}
+	}
+	summary.Terraform = module.summary
+	return summary, true
+}
+
+// newTerraformModule returns an empty accumulator
+func newTerraformModule() *terraformModule {
+	return &terraformModule{
+		summary:   &TerraformSummary{},
+		resources: make(map[string]int),
+		providers: make(map[string]int),
+	}
+}
+
+// addFile parses one file into the module and returns counts of what it
+// defines, e.g. "3 resources"
+func (m *terraformModule) addFile(name string, content []byte) ([]string, error) {
+	file, diagnostics := hclsyntax.ParseConfig(content, name, hcl.InitialPos)
+	if diagnostics.HasErrors() {
+		return nil, hclError(diagnostics)
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, nil
+	}
+
+	kinds := make(map[string]int)
+	var kindOrder []string
+	count := func(kind string) {
+		if kinds[kind] == 0 {
+			kindOrder = append(kindOrder, kind)
+		}
+		kinds[kind]++
+	}
+
+	for _, attribute := range hclAttributes(body) {
+		m.attributes = append(m.attributes, attribute.Name)
+	}
+	for _, block := range body.Blocks {
+		label := func(index int) string {
+			if index < len(block.Labels) {
+				return block.Labels[index]
+			}
+			return ""
+		}
+
+		switch block.Type {
+		case "terraform":
+			count("terraform block")
+			m.terraformBlock(block.Body, content)
+		case "provider":
+			count("provider")
+			name := label(0)
+			if alias := hclString(block.Body, "alias"); alias != "" {
+				m.summary.Providers = append(m.summary.Providers, name+"."+alias+": configured alias")
+			} else if _, exists := m.providers[name]; !exists {
+				m.providers[name] = len(m.summary.Providers)
+				m.summary.Providers = append(m.summary.Providers, name+": configured")
+			}
+		case "resource":
+			count("resource")
+			resourceType := label(0)
+			index, exists := m.resources[resourceType]
+			if !exists {
+				index = len(m.summary.Resources)
+				m.resources[resourceType] = index
+				m.summary.Resources = append(m.summary.Resources, Definition{Name: resourceType})
+			}
+			m.summary.Resources[index].Entries = append(m.summary.Resources[index].Entries, label(1)+hclRepetition(block.Body))
+		case "data":
+			count("data source")
+			m.summary.DataSources = append(m.summary.DataSources, label(0)+"."+label(1)+hclRepetition(block.Body))
+		case "module":
+			count("module")
+			source := hclString(block.Body, "source")
+			if version := hclString(block.Body, "version"); version != "" {
+				source += " " + version
+			}
+			m.summary.Modules = append(m.summary.Modules, label(0)+" ← "+source+hclRepetition(block.Body))
+		case "variable":
+			count("variable")
+			m.summary.Variables = append(m.summary.Variables, hclVariable(label(0), block.Body, content))
+		case "output":
+			count("output")
+			output := label(0)
+			if value, exists := block.Body.Attributes["value"]; exists {
+				output += " = " + hclSource(value.Expr, content)
+			}
+			if hclBool(block.Body, "sensitive") {
+				output += " (sensitive)"
+			}
+			m.summary.Outputs = append(m.summary.Outputs, output)
+		case "locals":
+			for _, attribute := range hclAttributes(block.Body) {
+				count("local")
+				m.summary.Locals = append(m.summary.Locals, attribute.Name)
+			}
+		default:
+			count("block")
+			described := block.Type
+			for _, blockLabel := range block.Labels {
+				described += fmt.Sprintf(" %q", blockLabel)
+			}
+			m.summary.Blocks = append(m.summary.Blocks, described)
+		}
+	}
+
+	var defined []string
+	for _, kind := range kindOrder {
+		defined = append(defined, pluralize(kinds[kind], kind))
+	}
+	return defined, nil
+}
+
+// terraformBlock reads the required version, providers and backend from a
+// terraform block
+func (m *terraformModule) terraformBlock(body *hclsyntax.Body, content []byte) {
+	if version := hclString(body, "required_version"); version != "" {
+		m.summary.RequiredVersion = version
+	}
+	// Terragrunt configurations point at the module they wrap
+	if source := hclString(body, "source"); source != "" {
+		m.summary.Modules = append(m.summary.Modules, "terraform ← "+source)
+	}
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "backend":
+			if len(block.Labels) > 0 {
+				m.summary.Backend = block.Labels[0]
+			}
+		case "cloud":
+			m.summary.Backend = "Terraform Cloud"
+			if organization := hclString(block.Body, "organization"); organization != "" {
+				m.summary.Backend += " (" + organization + ")"
+			}
+		case "required_providers":
+			for _, attribute := range hclAttributes(block.Body) {
+				entry := attribute.Name + ": " + hclProviderRequirement(attribute.Expr, content)
+				// The requirement is more telling than "configured", whichever
+				// file was read first
+				if index, exists := m.providers[attribute.Name]; exists {
+					m.summary.Providers[index] = entry
+					continue
+				}
+				m.providers[attribute.Name] = len(m.summary.Providers)
+				m.summary.Providers = append(m.summary.Providers, entry)
+			}
+		}
+	}
+}
+
+// hclProviderRequirement formats a required_providers entry, either the
+// object form with source and version or a legacy version string
+func hclProviderRequirement(expression hclsyntax.Expression, content []byte) string {
+	value, diagnostics := expression.Value(nil)
+	if diagnostics.HasErrors() || !value.IsKnown() || value.IsNull() {
+		return hclSource(expression, content)
+	}
+	if value.Type() == cty.String {
+		return value.AsString()
+	}
+	if !value.Type().IsObjectType() {
+		return hclSource(expression, content)
+	}
+	var parts []string
+	for _, key := range []string{"source", "version"} {
+		if value.Type().HasAttribute(key) {
+			if attribute := value.GetAttr(key); attribute.Type() == cty.String && attribute.IsKnown() && !attribute.IsNull() {
+				parts = append(parts, attribute.AsString())
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// hclVariable formats a variable block as "name: type = default", marking
+// variables without a default as required
+func hclVariable(name string, body *hclsyntax.Body, content []byte) string {
+	variable := name
+	if variableType, exists := body.Attributes["type"]; exists {
+		variable += ": " + hclSource(variableType.Expr, content)
+	}
+	if defaultValue, exists := body.Attributes["default"]; exists {
+		variable += " = " + hclSource(defaultValue.Expr, content)
+	} else {
+		variable += " (required)"
+	}
+	if hclBool(body, "sensitive") {
+		variable += " (sensitive)"
+	}
+	return variable
+}
+
+// hclRepetition marks blocks that use count or for_each
+func hclRepetition(body *hclsyntax.Body) string {
+	for _, meta := range []string{"count", "for_each"} {
+		if _, exists := body.Attributes[meta]; exists {
+			return " (" + meta + ")"
+		}
+	}
+	return ""
+}
+
+// hclAttributes returns the attributes of a body in source order
+func hclAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attributes := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attribute := range body.Attributes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].SrcRange.Start.Byte < attributes[j].SrcRange.Start.Byte
+	})
+	return attributes
+}
+
+// hclString returns the value of a literal string attribute, or ""
+func hclString(body *hclsyntax.Body, name string) string {
+	attribute, exists := body.Attributes[name]
+	if !exists {
+		return ""
+	}
+	value, diagnostics := attribute.Expr.Value(nil)
+	if diagnostics.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return ""
+	}
+	return value.AsString()
+}
+
+// hclBool reports whether a literal boolean attribute is true
+func hclBool(body *hclsyntax.Body, name string) bool {
+	attribute, exists := body.Attributes[name]
+	if !exists {
+		return false
+	}
+	value, diagnostics := attribute.Expr.Value(nil)
+	return !diagnostics.HasErrors() && value.IsKnown() && !value.IsNull() && value.Type() == cty.Bool && value.True()
+}
+
+// hclSource returns the source text of an expression on one line, shortened
+// when long
+func hclSource(expression hclsyntax.Expression, content []byte) string {
+	sourceRange := expression.Range()
+	if sourceRange.End.Byte > len(content) || sourceRange.Start.Byte > sourceRange.End.Byte {
+		return ""
+	}
+	source := normalizeSpace(string(content[sourceRange.Start.Byte:sourceRange.End.Byte]))
+	if runes := []rune(source); len(runes) > maxHCLExpression {
+		source = string(runes[:maxHCLExpression-3]) + "..."
+	}
+	return source
+}
+
+// hclError returns the first error of a set of diagnostics with its line
+func hclError(diagnostics hcl.Diagnostics) error {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity != hcl.DiagError {
+			continue
+		}
+		if diagnostic.Subject != nil {
+			return fmt.Errorf("line %d: %s", diagnostic.Subject.Start.Line, diagnostic.Summary)
+		}
+		return fmt.Errorf("%s", diagnostic.Summary)
+	}
+	return diagnostics
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlHeaderPattern matches [table] and [[array.of.tables]] header lines
+var tomlHeaderPattern = regexp.MustCompile(`^\s*\[\[?\s*([^\[\]#]+?)\s*\]\]?`)
+
+// parseTOML lists TOML tables as [table], arrays of tables as [[table]] and
+// every key, including dotted keys, in dotted form
+func (s *Summarizer) parseTOML(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var data map[string]interface{}
+	metadata, err := toml.Decode(string(content), &data)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Invalid TOML: %s", strings.TrimPrefix(err.Error(), "toml: "))
+		return summary
+	}
+
+	// Tables declared with a header, as opposed to inline tables
+	headers := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		if matches := tomlHeaderPattern.FindStringSubmatch(line); matches != nil {
+			headers[normalizeTOMLKey(matches[1])] = true
+		}
+	}
+
+	var keys []string
+	for _, key := range metadata.Keys() {
+		name := key.String()
+		switch metadata.Type(key...) {
+		case "ArrayHash":
+			keys = append(keys, fmt.Sprintf("[[%s]]", name))
+		case "Hash":
+			if headers[normalizeTOMLKey(name)] {
+				keys = append(keys, fmt.Sprintf("[%s]", name))
+			} else {
+				keys = append(keys, name)
+			}
+		default:
+			keys = append(keys, name)
+		}
+	}
+
+	// Keys repeat for every element of an array of tables
+	summary.ConfigKeys = uniqueStrings(keys)
+	return summary
+}
+
+// normalizeTOMLKey removes quotes and whitespace around the parts of a dotted key
+func normalizeTOMLKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
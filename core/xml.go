@@ -0,0 +1,404 @@
+package core
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultXMLDepth is how many levels of the element tree are summarized,
+// counting the root element as the first level
+const DefaultXMLDepth = 4
+
+// XMLSummary describes the structure of an XML document
+type XMLSummary struct {
+	Root       string   // Root element name, with namespace prefix
+	Dialect    string   // Recognized document type, e.g. "Maven POM"
+	Namespaces []string // Declared namespaces as "prefix = URI"
+	Elements   []string // Indented element tree with occurrence counts
+	Attributes []string // Attribute names with occurrence counts
+	Details    []string // Dialect-specific facts such as dependencies
+}
+
+// xmlNode is an element of a parsed document, kept only for dialects that
+// need their content
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     strings.Builder
+	children []*xmlNode
+}
+
+// xmlTreeNode aggregates sibling elements with the same name
+type xmlTreeNode struct {
+	name     string
+	count    int
+	children []*xmlTreeNode
+	index    map[string]*xmlTreeNode
+}
+
+// SetXMLDepth sets how many levels of XML element trees are summarized
+func (s *Summarizer) SetXMLDepth(depth int) {
+	if depth > 0 {
+		s.xmlDepth = depth
+	}
+}
+
+// parseXML summarizes the root element, namespaces, element tree and
+// attributes of an XML document, with extra detail for known dialects
+func (s *Summarizer) parseXML(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	maxDepth := s.xmlDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultXMLDepth
+	}
+
+	info := &XMLSummary{}
+	root := &xmlTreeNode{index: make(map[string]*xmlTreeNode)}
+	treeStack := []*xmlTreeNode{root}
+	var nodeStack []*xmlNode
+	var document *xmlNode
+	attributeCounts := make(map[string]int)
+	var attributeOrder []string
+
+	// RawToken keeps namespace prefixes as written, so tag matching is checked here
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			summary.Error = fmt.Sprintf("Invalid XML: %s", xmlErrorMessage(err, decoder))
+			return summary
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := xmlName(t.Name)
+			if info.Root == "" {
+				info.Root = name
+				info.Dialect = xmlDialect(fullPath, t)
+				if info.Dialect != "" {
+					document = &xmlNode{}
+					nodeStack = []*xmlNode{document}
+				}
+			} else if len(treeStack) == 1 {
+				line, _ := decoder.InputPos()
+				summary.Error = fmt.Sprintf("Invalid XML: line %d: more than one root element", line)
+				return summary
+			}
+
+			parent := treeStack[len(treeStack)-1]
+			child := parent.index[name]
+			if child == nil {
+				child = &xmlTreeNode{name: name, index: make(map[string]*xmlTreeNode)}
+				parent.index[name] = child
+				parent.children = append(parent.children, child)
+			}
+			child.count++
+			treeStack = append(treeStack, child)
+
+			attrs := make(map[string]string)
+			for _, attr := range t.Attr {
+				attrName := xmlName(attr.Name)
+				attrs[attrName] = attr.Value
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					info.Namespaces = append(info.Namespaces, "(default) = "+attr.Value)
+				case attr.Name.Space == "xmlns":
+					info.Namespaces = append(info.Namespaces, attr.Name.Local+" = "+attr.Value)
+				default:
+					if attributeCounts[attrName] == 0 {
+						attributeOrder = append(attributeOrder, attrName)
+					}
+					attributeCounts[attrName]++
+				}
+			}
+
+			if document != nil {
+				node := &xmlNode{name: name, attrs: attrs}
+				parentNode := nodeStack[len(nodeStack)-1]
+				parentNode.children = append(parentNode.children, node)
+				nodeStack = append(nodeStack, node)
+			}
+
+		case xml.EndElement:
+			name := xmlName(t.Name)
+			if len(treeStack) == 1 || treeStack[len(treeStack)-1].name != name {
+				line, _ := decoder.InputPos()
+				summary.Error = fmt.Sprintf("Invalid XML: line %d: unexpected closing tag </%s>", line, name)
+				return summary
+			}
+			treeStack = treeStack[:len(treeStack)-1]
+			if document != nil {
+				nodeStack = nodeStack[:len(nodeStack)-1]
+			}
+
+		case xml.CharData:
+			if document != nil && len(nodeStack) > 1 {
+				nodeStack[len(nodeStack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if info.Root == "" {
+		summary.Error = "Invalid XML: no root element"
+		return summary
+	}
+	if len(treeStack) > 1 {
+		summary.Error = fmt.Sprintf("Invalid XML: unexpected end of file, <%s> is not closed", treeStack[len(treeStack)-1].name)
+		return summary
+	}
+
+	for _, child := range root.children {
+		info.Elements = append(info.Elements, formatXMLTree(child, 0, maxDepth)...)
+	}
+	info.Attributes = formatCounts(attributeOrder, attributeCounts)
+
+	if document != nil && len(document.children) > 0 {
+		info.Details = xmlDialectDetails(info.Dialect, document.children[0])
+		summary.Language = info.Dialect
+	}
+
+	summary.XML = info
+	return summary
+}
+
+// xmlName formats an element or attribute name with its namespace prefix
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// xmlErrorMessage formats a decoder error with the line it occurred on
+func xmlErrorMessage(err error, decoder *xml.Decoder) string {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("line %d: %s", syntaxErr.Line, syntaxErr.Msg)
+	}
+	line, _ := decoder.InputPos()
+	return fmt.Sprintf("line %d: %v", line, err)
+}
+
+// formatXMLTree renders an aggregated element and its descendants as
+// indented lines such as "  dependency (12)", stopping at maxDepth
+func formatXMLTree(node *xmlTreeNode, depth, maxDepth int) []string {
+	if depth >= maxDepth {
+		return nil
+	}
+
+	line := strings.Repeat("  ", depth) + node.name
+	if node.count > 1 {
+		line += fmt.Sprintf(" (%d)", node.count)
+	}
+	if depth == maxDepth-1 && len(node.children) > 0 {
+		line += " …"
+	}
+
+	lines := []string{line}
+	for _, child := range node.children {
+		lines = append(lines, formatXMLTree(child, depth+1, maxDepth)...)
+	}
+	return lines
+}
+
+// xmlDialect recognizes well-known XML document types from the file name and
+// root element
+func xmlDialect(fullPath string, root xml.StartElement) string {
+	fileName := strings.ToLower(filepath.Base(fullPath))
+	namespace := ""
+	hasSdk := false
+	hasAndroid := false
+	for _, attr := range root.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			namespace = attr.Value
+		}
+		if attr.Name.Space == "xmlns" && attr.Name.Local == "android" {
+			hasAndroid = true
+		}
+		if attr.Name.Local == "Sdk" {
+			hasSdk = true
+		}
+	}
+
+	switch {
+	case root.Name.Local == "project" && (fileName == "pom.xml" || strings.Contains(namespace, "maven.apache.org/POM")):
+		return "Maven POM"
+	case root.Name.Local == "manifest" && (hasAndroid || fileName == "androidmanifest.xml"):
+		return "Android Manifest"
+	case root.Name.Local == "svg":
+		return "SVG"
+	case root.Name.Local == "Project" && (hasSdk || strings.HasSuffix(fileName, "proj") || strings.Contains(namespace, "schemas.microsoft.com/developer/msbuild")):
+		return "MSBuild Project"
+	}
+	return ""
+}
+
+// xmlDialectDetails extracts the facts that matter for a recognized dialect
+func xmlDialectDetails(dialect string, root *xmlNode) []string {
+	var details []string
+	add := func(label, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			details = append(details, label+": "+value)
+		}
+	}
+
+	switch dialect {
+	case "Maven POM":
+		parent := root.child("parent")
+		groupID := root.childText("groupId")
+		version := root.childText("version")
+		if parent != nil {
+			add("Parent", mavenCoordinates(parent))
+			// Coordinates are inherited from the parent when omitted
+			if groupID == "" {
+				groupID = parent.childText("groupId")
+			}
+			if version == "" {
+				version = parent.childText("version")
+			}
+		}
+		add("Artifact", joinCoordinates(groupID, root.childText("artifactId"), version))
+		add("Packaging", root.childText("packaging"))
+		for _, module := range root.child("modules").all("module") {
+			add("Module", module.text.String())
+		}
+		for _, dependency := range root.child("dependencies").all("dependency") {
+			entry := mavenCoordinates(dependency)
+			if scope := dependency.childText("scope"); scope != "" {
+				entry += " (" + scope + ")"
+			}
+			add("Dependency", entry)
+		}
+		for _, plugin := range root.child("build").child("plugins").all("plugin") {
+			add("Plugin", mavenCoordinates(plugin))
+		}
+
+	case "Android Manifest":
+		add("Package", root.attrs["package"])
+		if sdk := root.child("uses-sdk"); sdk != nil {
+			add("Min SDK", sdk.attrs["android:minSdkVersion"])
+			add("Target SDK", sdk.attrs["android:targetSdkVersion"])
+		}
+		for _, permission := range root.all("uses-permission") {
+			add("Permission", permission.attrs["android:name"])
+		}
+		application := root.child("application")
+		for _, kind := range []string{"activity", "service", "receiver", "provider"} {
+			for _, component := range application.all(kind) {
+				name := component.attrs["android:name"]
+				if isLauncherActivity(component) {
+					name += " (launcher)"
+				}
+				add(strings.ToUpper(kind[:1])+kind[1:], name)
+			}
+		}
+
+	case "SVG":
+		if root.attrs["width"] != "" || root.attrs["height"] != "" {
+			add("Size", root.attrs["width"]+" × "+root.attrs["height"])
+		}
+		add("ViewBox", root.attrs["viewBox"])
+		add("Title", root.childText("title"))
+
+	case "MSBuild Project":
+		add("SDK", root.attrs["Sdk"])
+		for _, group := range root.all("PropertyGroup") {
+			add("Target framework", group.childText("TargetFramework"))
+			add("Target frameworks", group.childText("TargetFrameworks"))
+			add("Output type", group.childText("OutputType"))
+		}
+		for _, group := range root.all("ItemGroup") {
+			for _, reference := range group.all("PackageReference") {
+				version := reference.attrs["Version"]
+				if version == "" {
+					version = reference.childText("Version")
+				}
+				add("Package", strings.TrimSpace(reference.attrs["Include"]+" "+version))
+			}
+			for _, reference := range group.all("ProjectReference") {
+				add("Project reference", reference.attrs["Include"])
+			}
+		}
+	}
+
+	return details
+}
+
+// mavenCoordinates formats groupId:artifactId:version, leaving out missing parts
+func mavenCoordinates(node *xmlNode) string {
+	return joinCoordinates(node.childText("groupId"), node.childText("artifactId"), node.childText("version"))
+}
+
+// joinCoordinates joins the non-empty parts of Maven coordinates with ':'
+func joinCoordinates(parts ...string) string {
+	var present []string
+	for _, part := range parts {
+		if part != "" {
+			present = append(present, part)
+		}
+	}
+	return strings.Join(present, ":")
+}
+
+// isLauncherActivity reports whether an activity handles the MAIN/LAUNCHER intent
+func isLauncherActivity(activity *xmlNode) bool {
+	for _, filter := range activity.all("intent-filter") {
+		for _, category := range filter.all("category") {
+			if category.attrs["android:name"] == "android.intent.category.LAUNCHER" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// child returns the first child element with the given name; it is nil-safe
+// so lookups can be chained
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// all returns every child element with the given name
+func (n *xmlNode) all(name string) []*xmlNode {
+	if n == nil {
+		return nil
+	}
+	var matches []*xmlNode
+	for _, child := range n.children {
+		if child.name == name {
+			matches = append(matches, child)
+		}
+	}
+	return matches
+}
+
+// childText returns the trimmed text of the first child element with the given name
+func (n *xmlNode) childText(name string) string {
+	if child := n.child(name); child != nil {
+		return strings.TrimSpace(child.text.String())
+	}
+	return ""
+}
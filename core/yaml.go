@@ -0,0 +1,225 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentSummary describes one document of a multi-document file
+type DocumentSummary struct {
+	Title string   // e.g. "Document 2: Deployment web"
+	Keys  []string // Nested keys in dotted form
+}
+
+// yamlUnknownAnchorPattern matches the one parser error that carries no line number
+var yamlUnknownAnchorPattern = regexp.MustCompile(`unknown anchor '([^']+)' referenced`)
+
+// maxYAMLDepth bounds recursion through aliases that refer to their own parents
+const maxYAMLDepth = 8
+
+// maxYAMLKeys bounds the number of keys extracted from one document
+const maxYAMLKeys = 2000
+
+// parseYAML extracts the nested key tree of each document in a YAML stream,
+// resolving anchors, aliases and merge keys
+func (s *Summarizer) parseYAML(fullPath string, summary FileSummary) FileSummary {
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		summary.Error = fmt.Sprintf("Error reading file: %v", err)
+		return summary
+	}
+	summary.LineCount = strings.Count(string(content), "\n") + 1
+
+	var documents []DocumentSummary
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for index := 1; ; index++ {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			summary.Error = fmt.Sprintf("Invalid YAML in document %d: %s", index, yamlErrorMessage(err, content))
+			return summary
+		}
+
+		// Skip empty documents, such as one after a trailing "---"
+		if len(document.Content) == 0 || (document.Content[0].Tag == "!!null" && document.Content[0].Value == "") {
+			continue
+		}
+
+		documents = append(documents, DocumentSummary{
+			Title: yamlDocumentTitle(&document, index),
+			Keys:  uniqueStrings(extractYAMLKeys(&document, "", 0)),
+		})
+	}
+
+	// A single document is summarized like any other config file
+	if len(documents) == 1 {
+		summary.ConfigKeys = documents[0].Keys
+	} else {
+		summary.Documents = documents
+	}
+	return summary
+}
+
+// yamlKeyWalker extracts keys from a YAML node tree. Each anchor is expanded
+// once per key prefix and the total number of keys produced is capped, so
+// files that fan out through many nested merge keys stay cheap to summarize.
+type yamlKeyWalker struct {
+	expanded map[yamlExpansion][]string
+	produced int
+}
+
+// yamlExpansion identifies an anchored node expanded under a key prefix
+type yamlExpansion struct {
+	node   *yaml.Node
+	prefix string
+}
+
+// extractYAMLKeys recursively extracts keys from a YAML node, following
+// aliases and merge keys (<<: *anchor) like extractJSONKeys does for JSON
+func extractYAMLKeys(node *yaml.Node, prefix string, depth int) []string {
+	walker := &yamlKeyWalker{expanded: make(map[yamlExpansion][]string)}
+	return walker.keys(node, prefix, depth)
+}
+
+// keys returns the keys below node, reusing earlier expansions of aliases
+func (w *yamlKeyWalker) keys(node *yaml.Node, prefix string, depth int) []string {
+	if node == nil || depth > maxYAMLDepth || w.produced >= maxYAMLKeys {
+		return nil
+	}
+
+	var keys []string
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			keys = append(keys, w.keys(child, prefix, depth)...)
+		}
+	case yaml.AliasNode:
+		keys = append(keys, w.alias(node.Alias, prefix, depth+1)...)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			// Merge keys pull in the keys of one or more aliased mappings
+			if key.Tag == "!!merge" {
+				sources := []*yaml.Node{value}
+				if value.Kind == yaml.SequenceNode {
+					sources = value.Content
+				}
+				for _, source := range sources {
+					keys = append(keys, w.keys(source, prefix, depth+1)...)
+				}
+				continue
+			}
+
+			if w.produced >= maxYAMLKeys {
+				break
+			}
+			fullKey := key.Value
+			if prefix != "" {
+				fullKey = prefix + "." + key.Value
+			}
+			keys = append(keys, fullKey)
+			w.produced++
+
+			// Recursively extract nested keys (limited depth)
+			if strings.Count(fullKey, ".") < 3 {
+				keys = append(keys, w.keys(value, fullKey, depth+1)...)
+			}
+		}
+	case yaml.SequenceNode:
+		if len(node.Content) > 0 {
+			// Analyze first array element
+			keys = append(keys, w.keys(node.Content[0], prefix+"[0]", depth+1)...)
+		}
+	}
+
+	return keys
+}
+
+// alias expands the node an alias refers to, once per prefix
+func (w *yamlKeyWalker) alias(target *yaml.Node, prefix string, depth int) []string {
+	expansion := yamlExpansion{node: target, prefix: prefix}
+	if keys, done := w.expanded[expansion]; done {
+		return keys
+	}
+	// Mark the expansion before walking it, so an alias inside its own
+	// anchor contributes nothing instead of recursing
+	w.expanded[expansion] = nil
+	keys := uniqueStrings(w.keys(target, prefix, depth))
+	w.expanded[expansion] = keys
+	return keys
+}
+
+// yamlDocumentTitle names a document, using Kubernetes-style kind and
+// metadata.name when present
+func yamlDocumentTitle(document *yaml.Node, index int) string {
+	title := fmt.Sprintf("Document %d", index)
+	root := document.Content[0]
+	kind := yamlScalar(yamlLookup(root, "kind"))
+	name := yamlScalar(yamlLookup(yamlLookup(root, "metadata"), "name"))
+	if kind != "" {
+		title += ": " + strings.TrimSpace(kind+" "+name)
+	}
+	return title
+}
+
+// yamlLookup returns the value of key in a mapping node, or nil
+func yamlLookup(node *yaml.Node, key string) *yaml.Node {
+	if node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlScalar returns the value of a scalar node, or "" for anything else
+func yamlScalar(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// yamlErrorMessage strips the "yaml: " prefix from parser errors and adds a
+// line number to errors that lack one
+func yamlErrorMessage(err error, content []byte) string {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	if matches := yamlUnknownAnchorPattern.FindStringSubmatch(message); matches != nil {
+		for i, line := range strings.Split(string(content), "\n") {
+			if strings.Contains(line, "*"+matches[1]) {
+				return fmt.Sprintf("line %d: %s", i+1, message)
+			}
+		}
+	}
+	return message
+}
+
+// uniqueStrings removes repeated entries, keeping the first occurrence
+func uniqueStrings(items []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
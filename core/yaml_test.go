@@ -0,0 +1,62 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseYAMLAnchors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		keys    []string
+	}{
+		{
+			name:    "merge key",
+			content: "base: &base\n  a: 1\n  b: 2\nchild:\n  <<: *base\n  c: 3\n",
+			keys:    []string{"base", "base.a", "base.b", "child", "child.a", "child.b", "child.c"},
+		},
+		{
+			name:    "merge list",
+			content: "x: &x\n  a: 1\ny: &y\n  b: 2\nz:\n  <<: [*x, *y]\n",
+			keys:    []string{"x", "x.a", "y", "y.b", "z", "z.a", "z.b"},
+		},
+		{
+			name:    "alias value",
+			content: "defaults: &defaults\n  port: 80\nserver: *defaults\n",
+			keys:    []string{"defaults", "defaults.port", "server", "server.port"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizeContent(t, "config.yaml", tt.content)
+			if summary.Error != "" {
+				t.Fatalf("Error = %q", summary.Error)
+			}
+			assertStrings(t, "ConfigKeys", summary.ConfigKeys, tt.keys)
+		})
+	}
+}
+
+func TestParseYAMLMergeFanOut(t *testing.T) {
+	// Six levels of 400 merges each would take forever if every alias were
+	// expanded from scratch
+	var content strings.Builder
+	content.WriteString("l0: &l0\n  a: 1\n")
+	for level := 1; level <= 6; level++ {
+		aliases := strings.TrimSuffix(strings.Repeat(fmt.Sprintf("*l%d, ", level-1), 400), ", ")
+		fmt.Fprintf(&content, "l%d: &l%d\n  k%d: 1\n  <<: [%s]\n", level, level, level, aliases)
+	}
+
+	start := time.Now()
+	summary := summarizeContent(t, "fanout.yaml", content.String())
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("summarizing took %v", elapsed)
+	}
+	if len(summary.ConfigKeys) == 0 || len(summary.ConfigKeys) > maxYAMLKeys {
+		t.Errorf("got %d keys, want between 1 and %d", len(summary.ConfigKeys), maxYAMLKeys)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"parsec/core"
 	"parsec/ui"
@@ -24,6 +25,12 @@ const (
 	borderHeight = 2
 )
 
+// Follow mode constants
+const (
+	followInterval = 500 * time.Millisecond // How often a followed file is polled
+	maxFollowLines = 500                    // Streamed lines kept in the summary pane
+)
+
 // Application model containing the main state
 type model struct {
 	fileListModel ui.FileListModel
@@ -41,6 +48,12 @@ type model struct {
 	searchQuery   string
 	allFiles      []utils.FileInfo // All files in current directory for filtering
 	filteredFiles []utils.FileInfo // Files after fuzzy search filter
+
+	// Follow state
+	follower    *core.Follower // Nil unless a file is being followed
+	followPath  string         // Selected path being followed, set while starting
+	followLines []string       // Most recent lines of the followed file
+	followCount int            // Total lines in the followed file
 }
 
 // SummaryMsg is sent when a file summary is ready
@@ -50,6 +63,15 @@ type SummaryMsg struct {
 	selectedPath string // The path as selected in the file list
 }
 
+// FollowMsg is sent when a followed file has been opened or polled
+type FollowMsg struct {
+	follower     *core.Follower
+	update       core.FollowUpdate
+	err          error
+	selectedPath string
+	opened       bool // Set on the first message, when the file was just opened
+}
+
 func (m model) Init() tea.Cmd {
 	return loadFilesCmd(m.walker, m.currentDir)
 }
@@ -92,11 +114,37 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case FollowMsg:
+		// Drop updates from a follower that has since been stopped
+		current := msg.follower == m.follower
+		if msg.opened {
+			current = m.follower == nil
+		}
+		if msg.selectedPath != m.followPath || !current {
+			if msg.follower != nil && msg.follower != m.follower {
+				msg.follower.Close()
+			}
+			return m, nil
+		}
+		if msg.err != nil {
+			path := m.followPath
+			m.stopFollowing()
+			m.summaryModel.SetContent(fmt.Sprintf("File: %s\n\nFollowing stopped: %v", path, msg.err))
+			return m, nil
+		}
+		m.follower = msg.follower
+		m.applyFollowUpdate(msg.update)
+		return m, followTickCmd(m.follower, m.followPath)
+
 	case DirectoryPreviewMsg:
 		// Update summary with directory preview
 		if selected := m.fileListModel.GetSelectedFile(); selected != nil && selected.Path == msg.dirName {
-			m.summaryModel.SetSummary(nil)
-			m.summaryModel.SetContent(msg.content)
+			if msg.module != nil {
+				m.summaryModel.SetSummary(msg.module)
+			} else {
+				m.summaryModel.SetSummary(nil)
+				m.summaryModel.SetContent(msg.content)
+			}
 		}
 		return m, nil
 
@@ -156,6 +204,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Refresh file list
 			return m, loadFilesCmd(m.walker, m.currentDir)
 
+		case "x":
+			// Run the selected executable for its help text, on explicit request only
+			if selected := m.fileListModel.GetSelectedFile(); selected != nil && !selected.IsDir {
+				fullPath := filepath.Join(m.currentDir, selected.Path)
+				if utils.IsExecutableFile(fullPath) {
+					relPath, _ := filepath.Rel(m.basePath, fullPath)
+					m.stopFollowing()
+					m.summaryModel.SetLoading(true)
+					return m, executableHelpCmd(m.summarizer, relPath, selected.Path)
+				}
+			}
+			return m, nil
+
+		case "f":
+			// Toggle following the selected file, like tail -f
+			if m.followPath != "" {
+				path := m.followPath
+				m.stopFollowing()
+				if selected := m.fileListModel.GetSelectedFile(); selected != nil && selected.Path == path {
+					return m, m.handleFileSelection(selected)
+				}
+				return m, nil
+			}
+			if selected := m.fileListModel.GetSelectedFile(); selected != nil && !selected.IsDir {
+				m.followPath = selected.Path
+				m.summaryModel.SetLoading(true)
+				return m, followStartCmd(filepath.Join(m.currentDir, selected.Path), selected.Path)
+			}
+			return m, nil
+
 		case "pgup":
 			// Scroll summary up
 			m.summaryModel.Scroll(-5)
@@ -176,6 +254,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Go into the selected directory
 					m.currentDir = filepath.Join(m.currentDir, selected.Path)
 				}
+				m.stopFollowing()
 				// Load files from new directory
 				return m, loadFilesCmd(m.walker, m.currentDir)
 			}
@@ -235,6 +314,7 @@ func (m model) fuzzyFilter(files []utils.FileInfo, query string) []utils.FileInf
 type DirectoryPreviewMsg struct {
 	dirName string
 	content string
+	module  *core.FileSummary // Combined summary when the directory is a module
 }
 
 // showDirectoryPreview shows a preview of directory contents in the summary pane
@@ -243,6 +323,15 @@ func (m *model) showDirectoryPreview(dirName string) tea.Cmd {
 		// Construct full path to the directory
 		dirPath := filepath.Join(m.currentDir, dirName)
 
+		// Directories of Terraform files are summarized as one module
+		relDirPath, _ := filepath.Rel(m.basePath, dirPath)
+		if module, found := m.summarizer.SummarizeDirectory(relDirPath); found {
+			return DirectoryPreviewMsg{
+				dirName: dirName,
+				module:  &module,
+			}
+		}
+
 		// Get directory contents
 		files, err := m.walker.ListDirectory(dirPath)
 		if err != nil {
@@ -324,8 +413,8 @@ func (m model) formatDirectoryPreview(dirName string, files []utils.FileInfo, di
 		if file.IsDir {
 			icon = "📁"
 		} else {
-			// Get file icon based on extension
-			icon = utils.GetFileIcon(file.Extension)
+			// Get file icon based on name or extension
+			icon = utils.GetFileIconForName(file.Name)
 		}
 
 		result.WriteString(fmt.Sprintf("  %s %s", icon, file.Path))
@@ -345,6 +434,9 @@ func (m model) formatDirectoryPreview(dirName string, files []utils.FileInfo, di
 
 // handleFileSelection processes file selection and starts summarization if appropriate
 func (m *model) handleFileSelection(selected *utils.FileInfo) tea.Cmd {
+	// A new selection ends following the previous one
+	m.stopFollowing()
+
 	if selected == nil {
 		m.summaryModel.SetSummary(nil)
 		return nil
@@ -373,11 +465,11 @@ func (m *model) handleFileSelection(selected *utils.FileInfo) tea.Cmd {
 
 	// Handle file selection
 	fullPath := filepath.Join(m.currentDir, selected.Path)
-	if utils.IsSourceFile(selected.Path) || utils.IsExecutableFile(fullPath) {
+	// Create relative path for summarization
+	relPath, _ := filepath.Rel(m.basePath, fullPath)
+	if m.summarizer.CanSummarize(relPath) || utils.IsExecutableFile(fullPath) {
 		// Start loading summary for the new selection
 		m.summaryModel.SetLoading(true)
-		// Create relative path for summarization
-		relPath, _ := filepath.Rel(m.basePath, fullPath)
 		return summarizeFileCmd(m.summarizer, relPath, selected.Path)
 	} else {
 		// Show a simple message for unsupported files
@@ -387,6 +479,35 @@ func (m *model) handleFileSelection(selected *utils.FileInfo) tea.Cmd {
 	}
 }
 
+// applyFollowUpdate appends newly streamed lines and refreshes the summary pane
+func (m *model) applyFollowUpdate(update core.FollowUpdate) {
+	switch {
+	case update.Rotated:
+		m.followLines = append(m.followLines, "── file replaced, following the new file ──")
+	case update.Truncated:
+		m.followLines = append(m.followLines, "── file truncated, reading from the start ──")
+	}
+	m.followLines = append(m.followLines, update.Lines...)
+	if len(m.followLines) > maxFollowLines {
+		m.followLines = m.followLines[len(m.followLines)-maxFollowLines:]
+	}
+	m.followCount = update.LineCount
+
+	relPath, _ := filepath.Rel(m.basePath, filepath.Join(m.currentDir, m.followPath))
+	m.summaryModel.SetFollowing(relPath, m.followCount, m.followLines)
+}
+
+// stopFollowing closes the followed file, if any
+func (m *model) stopFollowing() {
+	if m.follower != nil {
+		m.follower.Close()
+	}
+	m.follower = nil
+	m.followPath = ""
+	m.followLines = nil
+	m.followCount = 0
+}
+
 // sizeComponents updates component dimensions based on current window size
 func (m model) sizeComponents() model {
 	if m.width <= 0 || m.height <= 0 {
@@ -490,9 +611,13 @@ func (m model) View() string {
 			lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(" (ESC to cancel, Enter to confirm)")
 	} else {
 		// Show regular help
+		help := "↑/↓ navigate • Enter to open • / search • PgUp/PgDn scroll • t toggle dirs • r refresh • x run --help • f follow • q quit"
+		if m.followPath != "" {
+			help = fmt.Sprintf("Following %s (%d lines) • f stop following • PgUp/PgDn scroll • q quit", m.followPath, m.followCount)
+		}
 		footer = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241")).
-			Render("↑/↓ navigate • Enter to open • / search • PgUp/PgDn scroll • t toggle dirs • r refresh • q quit")
+			Render(help)
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
@@ -544,10 +669,35 @@ func summarizeFileCmd(summarizer *core.Summarizer, filePath string, selectedPath
 	}
 }
 
+// followStartCmd opens a file for following and reads its last lines
+func followStartCmd(fullPath string, selectedPath string) tea.Cmd {
+	return func() tea.Msg {
+		follower, update, err := core.NewFollower(fullPath)
+		return FollowMsg{follower: follower, update: update, err: err, selectedPath: selectedPath, opened: true}
+	}
+}
+
+// followTickCmd polls a followed file for appended lines after a short delay
+func followTickCmd(follower *core.Follower, selectedPath string) tea.Cmd {
+	return tea.Tick(followInterval, func(time.Time) tea.Msg {
+		update, err := follower.Poll()
+		return FollowMsg{follower: follower, update: update, err: err, selectedPath: selectedPath}
+	})
+}
+
+// executableHelpCmd summarizes an executable and runs it for its help text
+func executableHelpCmd(summarizer *core.Summarizer, filePath string, selectedPath string) tea.Cmd {
+	return func() tea.Msg {
+		summary := summarizer.SummarizeFile(filePath)
+		summary.ExecutableHelp = summarizer.ExecutableHelp(filePath)
+		return SummaryMsg{summary: summary, path: filePath, selectedPath: selectedPath}
+	}
+}
+
 func main() {
 	// Set custom usage function to show comprehensive help
 	flag.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), `Usage: parsec [directory]
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage: parsec [options] [directory]
 
 Navigate and summarize files in a terminal-based interface.
 
@@ -573,8 +723,15 @@ Keyboard Controls:
   Home/End      Jump to first/last file
   t             Toggle directory visibility
   r             Refresh current directory
+  x             Run selected executable with --help
+  f             Follow the selected file as it grows (like tail -f)
   q or Ctrl+C   Quit
 
+Options:
+  -allow-help paths   Comma-separated executable paths that are run with
+                      --help automatically (others are only inspected)
+  -xml-depth n        Levels of XML element trees to summarize (default 4)
+
 Search Mode:
   Type          Add characters to search query
   Backspace     Remove last character
@@ -583,6 +740,8 @@ Search Mode:
 `)
 	}
 
+	allowHelp := flag.String("allow-help", "", "comma-separated executable paths that are run with --help automatically")
+	xmlDepth := flag.Int("xml-depth", core.DefaultXMLDepth, "levels of XML element trees to summarize")
 	flag.Parse()
 
 	// Get directory from positional argument or use current directory
@@ -604,7 +763,11 @@ Search Mode:
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(initialModel(absPath), tea.WithAltScreen())
+	m := initialModel(absPath)
+	m.summarizer.AllowHelp(strings.Split(*allowHelp, ",")...)
+	m.summarizer.SetXMLDepth(*xmlDepth)
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
@@ -184,7 +184,7 @@ func (m FileListModel) View() string {
 				indicator = "📁"
 			}
 		} else {
-			indicator = utils.GetFileIcon(file.Extension)
+			indicator = utils.GetFileIconForName(file.Name)
 		}
 
 		// Selection indicator (ensure consistent 1-character width)
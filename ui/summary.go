@@ -3,11 +3,13 @@ package ui
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"parsec/core"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // SummaryModel handles the right pane summary display
@@ -22,6 +24,7 @@ type SummaryModel struct {
 	errorStyle  lipgloss.Style
 	loadingText string
 	isLoading   bool
+	following   bool // Showing the live tail of a followed file
 }
 
 // NewSummaryModel creates a new summary model
@@ -49,6 +52,7 @@ func NewSummaryModel() SummaryModel {
 func (m *SummaryModel) SetSummary(summary *core.FileSummary) {
 	m.summary = summary
 	m.isLoading = false
+	m.following = false
 	m.scrollPos = 0
 
 	if summary == nil {
@@ -71,10 +75,35 @@ func (m *SummaryModel) SetLoading(loading bool) {
 func (m *SummaryModel) SetContent(content string) {
 	m.summary = nil
 	m.isLoading = false
+	m.following = false
 	m.scrollPos = 0
 	m.content = content
 }
 
+// SetFollowing shows the live tail of a followed file. The view sticks to the
+// newest line unless the user has scrolled up to read earlier ones.
+func (m *SummaryModel) SetFollowing(path string, lineCount int, lines []string) {
+	atBottom := !m.following || m.scrollPos >= m.maxScroll()
+
+	var result strings.Builder
+	result.WriteString(m.titleStyle.Render("📡 Following: "+m.formatPath(path)) + "\n")
+	result.WriteString(fmt.Sprintf("Lines: %d\n\n", lineCount))
+	if len(lines) == 0 {
+		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Waiting for new lines..."))
+	}
+	result.WriteString(strings.Join(lines, "\n"))
+
+	m.summary = nil
+	m.isLoading = false
+	m.following = true
+	m.content = result.String()
+	if atBottom {
+		m.scrollPos = m.maxScroll()
+	} else {
+		m.Scroll(0)
+	}
+}
+
 // SetDimensions updates the model dimensions
 func (m *SummaryModel) SetDimensions(width, height int) {
 	m.width = width
@@ -83,6 +112,20 @@ func (m *SummaryModel) SetDimensions(width, height int) {
 
 // Scroll adjusts the scroll position
 func (m *SummaryModel) Scroll(delta int) {
+	maxScroll := m.maxScroll()
+
+	newPos := m.scrollPos + delta
+	if newPos < 0 {
+		newPos = 0
+	}
+	if newPos > maxScroll {
+		newPos = maxScroll
+	}
+	m.scrollPos = newPos
+}
+
+// maxScroll returns the scroll position that shows the last line of content
+func (m *SummaryModel) maxScroll() int {
 	lines := strings.Split(m.content, "\n")
 
 	// Use same height calculation as View method
@@ -91,19 +134,10 @@ func (m *SummaryModel) Scroll(delta int) {
 		availableHeight = 1
 	}
 
-	maxScroll := 0
 	if len(lines) > availableHeight {
-		maxScroll = len(lines) - availableHeight
-	}
-
-	newPos := m.scrollPos + delta
-	if newPos < 0 {
-		newPos = 0
-	}
-	if newPos > maxScroll {
-		newPos = maxScroll
+		return len(lines) - availableHeight
 	}
-	m.scrollPos = newPos
+	return 0
 }
 
 // View renders the summary
@@ -139,8 +173,8 @@ func (m SummaryModel) View() string {
 		line := lines[i]
 		// Truncate long lines to fit width
 		maxLineLength := m.width - 4 // Account for padding
-		if maxLineLength > 0 && len(line) > maxLineLength {
-			line = line[:maxLineLength-3] + "..."
+		if maxLineLength > 0 && ansi.StringWidth(line) > maxLineLength {
+			line = ansi.Truncate(line, maxLineLength, "...")
 		}
 		visibleLines = append(visibleLines, line)
 	}
@@ -165,6 +199,12 @@ func (m SummaryModel) formatSummaryForDisplay(summary core.FileSummary) string {
 
 	// Basic info
 	result.WriteString(fmt.Sprintf("Language: %s\n", summary.Language))
+	if summary.Package != "" {
+		result.WriteString(fmt.Sprintf("Package: %s\n", summary.Package))
+	}
+	if summary.Shebang != "" {
+		result.WriteString(fmt.Sprintf("Shebang: %s\n", summary.Shebang))
+	}
 	result.WriteString(fmt.Sprintf("Lines: %d\n", summary.LineCount))
 	if summary.FileSize > 0 {
 		result.WriteString(fmt.Sprintf("Size: %s\n", formatFileSize(summary.FileSize)))
@@ -172,16 +212,53 @@ func (m SummaryModel) formatSummaryForDisplay(summary core.FileSummary) string {
 	if summary.FunctionCount > 0 {
 		result.WriteString(fmt.Sprintf("Functions: %d\n", summary.FunctionCount))
 	}
+	if summary.Warning != "" {
+		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("⚠️ "+summary.Warning) + "\n")
+	}
 	result.WriteString("\n")
 
 	// Handle different content types
-	if summary.IsExecutable {
+	if summary.IsExecutable && summary.ExecutableHelp != "" {
 		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true).Render("⚙️ Executable Help:"))
 		result.WriteString("\n\n")
 		result.WriteString(summary.ExecutableHelp)
 		return result.String()
 	}
 
+	// Static binary inspection
+	if summary.Binary != nil {
+		binary := summary.Binary
+		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true).Render("⚙️ Binary:"))
+		result.WriteString("\n")
+		result.WriteString(fmt.Sprintf("  Format: %s %s\n", binary.Format, binary.Kind))
+		result.WriteString(fmt.Sprintf("  Architecture: %s\n", binary.Architecture))
+		linking := "dynamic"
+		if len(binary.Libraries) == 0 {
+			linking = "static"
+		}
+		result.WriteString(fmt.Sprintf("  Linking: %s\n", linking))
+		stripped := "no"
+		if binary.Stripped {
+			stripped = "yes"
+		}
+		result.WriteString(fmt.Sprintf("  Stripped: %s\n", stripped))
+		if binary.GoVersion != "" {
+			result.WriteString(fmt.Sprintf("  Go version: %s\n", binary.GoVersion))
+		}
+		if binary.GoModule != "" {
+			result.WriteString(fmt.Sprintf("  Module: %s\n", binary.GoModule))
+		}
+		result.WriteString("\n")
+
+		m.writeSection(&result, "🔗 Linked Libraries:", "214", binary.Libraries, 15)
+		m.writeSection(&result, "📦 Go Modules:", "99", binary.GoDeps, 15)
+		if summary.IsExecutable {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Press x to run with --help"))
+			result.WriteString("\n")
+		}
+		return result.String()
+	}
+
 	// For markdown files with rendered content
 	if summary.IsRendered && summary.RenderedContent != "" {
 		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true).Render("📝 Rendered Content:"))
@@ -200,6 +277,25 @@ func (m SummaryModel) formatSummaryForDisplay(summary core.FileSummary) string {
 		result.WriteString("\n")
 	}
 
+	// Head and structure of HTML documents
+	if summary.HTML != nil {
+		page := summary.HTML
+		if page.Title != "" {
+			result.WriteString(fmt.Sprintf("Title: %s\n", page.Title))
+		}
+		info := fmt.Sprintf("Elements: %d", page.Elements)
+		if page.Lang != "" {
+			info = fmt.Sprintf("Lang: %s • %s", page.Lang, info)
+		}
+		result.WriteString(info + "\n\n")
+
+		m.writeSection(&result, "🏷️ Meta:", "214", page.Meta, 15)
+		m.writeSection(&result, "📜 Scripts:", "220", page.Scripts, 10)
+		m.writeSection(&result, "🎨 Stylesheets:", "213", page.Stylesheets, 10)
+		m.writeSection(&result, "📝 Forms:", "114", page.Forms, 10)
+		m.writeSection(&result, "🆔 Element IDs:", "81", page.IDs, 20)
+	}
+
 	// Headers for markdown files
 	if len(summary.Headers) > 0 {
 		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("171")).Bold(true).Render("📋 Headers:"))
@@ -230,6 +326,383 @@ func (m SummaryModel) formatSummaryForDisplay(summary core.FileSummary) string {
 		result.WriteString("\n")
 	}
 
+	// XML structure
+	if summary.XML != nil {
+		result.WriteString(fmt.Sprintf("Root element: <%s>\n\n", summary.XML.Root))
+		if summary.XML.Dialect != "" {
+			m.writeSection(&result, fmt.Sprintf("🏷️ %s:", summary.XML.Dialect), "214", summary.XML.Details, 20)
+		}
+		m.writeSection(&result, "🌐 Namespaces:", "39", summary.XML.Namespaces, 10)
+		if len(summary.XML.Elements) > 0 {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("34")).Bold(true).Render("🌳 Element Tree:"))
+			result.WriteString("\n")
+			for i, element := range summary.XML.Elements {
+				if i < 30 { // Show max 30 elements
+					result.WriteString(fmt.Sprintf("  %s\n", element))
+				}
+			}
+			if len(summary.XML.Elements) > 30 {
+				result.WriteString(fmt.Sprintf("  ... and %d more\n", len(summary.XML.Elements)-30))
+			}
+			result.WriteString("\n")
+		}
+		m.writeSection(&result, "🏷️ Attributes:", "176", summary.XML.Attributes, 15)
+	}
+
+	// Tabular data
+	if summary.Table != nil {
+		table := summary.Table
+		delimiter := table.Delimiter
+		if delimiter == "\t" {
+			delimiter = "tab"
+		}
+		result.WriteString(fmt.Sprintf("Rows: %d • Columns: %d • Delimiter: %s\n\n", table.Rows, len(table.Columns), delimiter))
+
+		if len(table.Preview) > 0 {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true).Render("📋 Preview:"))
+			result.WriteString("\n")
+			for _, line := range formatTable(table.Preview, table.HasHeader, 20) {
+				result.WriteString("  " + line + "\n")
+			}
+			result.WriteString("\n")
+		}
+
+		var columns []string
+		for _, column := range table.Columns {
+			columns = append(columns, fmt.Sprintf("%s: %s (%d null, %d distinct)", column.Name, column.Type, column.Nulls, column.Distinct))
+		}
+		m.writeSection(&result, "🧱 Columns:", "214", columns, 30)
+	}
+
+	// Log analytics
+	if summary.Log != nil {
+		log := summary.Log
+		result.WriteString(fmt.Sprintf("Format: %s • Entries: %d\n", log.Format, log.Entries))
+		if !log.First.IsZero() {
+			result.WriteString(fmt.Sprintf("First: %s\n", log.First.Format("2006-01-02 15:04:05")))
+			result.WriteString(fmt.Sprintf("Last:  %s\n", log.Last.Format("2006-01-02 15:04:05")))
+		}
+		result.WriteString("\n")
+
+		m.writeSection(&result, "📶 Levels:", "214", log.Levels, 10)
+
+		if len(log.Histogram) > 1 {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(fmt.Sprintf("📈 Timeline (per %s):", log.BucketSize)))
+			result.WriteString("\n")
+			peak := 0
+			for _, bucket := range log.Histogram {
+				if bucket.Count > peak {
+					peak = bucket.Count
+				}
+			}
+			for _, bucket := range log.Histogram {
+				bar := 0
+				if peak > 0 {
+					bar = bucket.Count * 30 / peak
+				}
+				if bar == 0 && bucket.Count > 0 {
+					bar = 1
+				}
+				result.WriteString(fmt.Sprintf("  %s │%s %d\n", bucket.Start.Format("2006-01-02 15:04"), strings.Repeat("█", bar), bucket.Count))
+			}
+			result.WriteString("\n")
+		}
+
+		if len(log.RecentErrors) > 0 {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("🚨 Recent Errors:"))
+			result.WriteString("\n")
+			for _, line := range log.RecentErrors {
+				result.WriteString(fmt.Sprintf("  %s\n", line))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// Dockerfile stages and runtime settings
+	if summary.Docker != nil {
+		docker := summary.Docker
+		if docker.Entrypoint != "" {
+			result.WriteString(fmt.Sprintf("Entrypoint: %s\n", docker.Entrypoint))
+		}
+		if docker.Cmd != "" {
+			result.WriteString(fmt.Sprintf("Cmd: %s\n", docker.Cmd))
+		}
+		if len(docker.Ports) > 0 {
+			result.WriteString(fmt.Sprintf("Exposed ports: %s\n", strings.Join(docker.Ports, ", ")))
+		}
+		if docker.Entrypoint != "" || docker.Cmd != "" || len(docker.Ports) > 0 {
+			result.WriteString("\n")
+		}
+
+		m.writeSection(&result, "🐳 Stages:", "39", docker.Stages, 15)
+		m.writeSection(&result, "🖼️ Base Images:", "99", docker.BaseImages, 10)
+		m.writeSection(&result, "🎛️ Build Arguments:", "176", docker.Args, 15)
+		m.writeSection(&result, "🌿 Environment:", "114", docker.Env, 15)
+		m.writeSection(&result, "📥 Copied Sources:", "214", docker.Sources, 15)
+	}
+
+	// Project manifest
+	if summary.Manifest != nil {
+		manifest := summary.Manifest
+		name := strings.TrimSpace(manifest.Name + " " + manifest.Version)
+		if name != "" {
+			result.WriteString(fmt.Sprintf("Name: %s\n", name))
+		}
+		if manifest.Runtime != "" {
+			result.WriteString(fmt.Sprintf("Runtime: %s\n", manifest.Runtime))
+		}
+		result.WriteString(fmt.Sprintf("Dependencies: %d direct", len(manifest.Dependencies)))
+		if len(manifest.DevDependencies) > 0 {
+			result.WriteString(fmt.Sprintf(", %d dev", len(manifest.DevDependencies)))
+		}
+		if manifest.Indirect > 0 {
+			result.WriteString(fmt.Sprintf(", %d indirect", manifest.Indirect))
+		}
+		result.WriteString("\n\n")
+
+		m.writeSection(&result, "📦 Dependencies:", "99", manifest.Dependencies, 20)
+		m.writeSection(&result, "🧪 Dev Dependencies:", "141", manifest.DevDependencies, 15)
+		m.writeSection(&result, "🔀 Overrides:", "203", manifest.Overrides, 10)
+		m.writeSection(&result, "▶️ Scripts:", "114", manifest.Scripts, 15)
+	}
+
+	// Lockfile contents
+	if summary.Lock != nil {
+		lock := summary.Lock
+		result.WriteString(fmt.Sprintf("Format: %s • Packages: %d • Direct: %d\n", lock.Format, lock.Packages, lock.Direct))
+		if lock.Note != "" {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(lock.Note) + "\n")
+		}
+		result.WriteString("\n")
+
+		m.writeSection(&result, "⚠️ Non-registry Sources:", "196", lock.Sources, 15)
+		m.writeSection(&result, "👯 Duplicate Versions:", "214", lock.Duplicates, 15)
+		m.writeSection(&result, "🧬 Pulled in by:", "99", lock.Transitive, 20)
+	}
+
+	// Makefile, justfile and Taskfile targets
+	if summary.Tasks != nil {
+		tasks := summary.Tasks
+		result.WriteString(fmt.Sprintf("Runner: %s • Targets: %d\n", tasks.Runner, len(tasks.Targets)))
+		if tasks.Default != "" {
+			result.WriteString(fmt.Sprintf("Default: %s\n", tasks.Default))
+		}
+		result.WriteString("\n")
+
+		if len(tasks.Targets) > 0 {
+			helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true).Render("🎯 Targets:"))
+			result.WriteString("\n")
+			for i, target := range tasks.Targets {
+				if i == 25 { // Show max 25 targets
+					result.WriteString(fmt.Sprintf("  ... and %d more\n", len(tasks.Targets)-25))
+					break
+				}
+				line := "  • " + target.Name
+				if len(target.Prerequisites) > 0 {
+					line += " ← " + strings.Join(target.Prerequisites, ", ")
+				}
+				if target.Phony {
+					line += helpStyle.Render(" (phony)")
+				}
+				result.WriteString(line + "\n")
+				if target.Help != "" {
+					result.WriteString(helpStyle.Render("      "+target.Help) + "\n")
+				}
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// SQL statements and the schema objects they define
+	if summary.SQL != nil {
+		sql := summary.SQL
+		if sql.Migration != "" {
+			result.WriteString(fmt.Sprintf("Migration: %s\n", sql.Migration))
+		}
+		info := fmt.Sprintf("Statements: %d", sql.Statements)
+		if len(sql.Categories) > 0 {
+			info += " • " + strings.Join(sql.Categories, " • ")
+		}
+		result.WriteString(info + "\n\n")
+
+		m.writeSection(&result, "🧾 Statement Kinds:", "245", sql.Kinds, 15)
+
+		if len(sql.Tables) > 0 {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render("🗄️ Tables:"))
+			result.WriteString("\n")
+			for i, table := range sql.Tables {
+				if i == 20 { // Show max 20 tables
+					result.WriteString(fmt.Sprintf("  ... and %d more\n", len(sql.Tables)-20))
+					break
+				}
+				result.WriteString(fmt.Sprintf("  %s\n", table.Name))
+				for j, column := range table.Columns {
+					if j < 12 { // Show max 12 columns per table
+						result.WriteString(fmt.Sprintf("    • %s\n", column))
+					}
+				}
+				if len(table.Columns) > 12 {
+					result.WriteString(fmt.Sprintf("    ... and %d more\n", len(table.Columns)-12))
+				}
+			}
+			result.WriteString("\n")
+		}
+
+		m.writeSection(&result, "✏️ Altered:", "214", sql.Altered, 15)
+		m.writeSection(&result, "👁️ Views:", "81", sql.Views, 10)
+		m.writeSection(&result, "🔍 Indexes:", "150", sql.Indexes, 15)
+		m.writeSection(&result, "⚙️ Functions & Procedures:", "213", sql.Routines, 15)
+		m.writeSection(&result, "🧱 Other Objects:", "180", sql.Objects, 10)
+		m.writeSection(&result, "🗑️ Dropped:", "196", sql.Dropped, 10)
+	}
+
+	// Selectors and at-rules of stylesheets
+	if summary.CSS != nil {
+		css := summary.CSS
+		result.WriteString(fmt.Sprintf("Dialect: %s • Rules: %d • Selectors: %d • Classes: %d • IDs: %d\n\n",
+			css.Dialect, css.Rules, len(css.Selectors), css.Classes, css.IDs))
+
+		m.writeSection(&result, "🎯 Selectors:", "39", css.Selectors, 20)
+		m.writeSection(&result, "🎛️ Custom Properties:", "214", css.CustomProperties, 15)
+		m.writeSection(&result, "📱 Media Queries:", "81", css.Media, 10)
+		m.writeSection(&result, "🧩 Mixins & Functions:", "213", css.Mixins, 15)
+		m.writeSection(&result, "➕ Included Mixins:", "176", css.Includes, 10)
+		m.writeSection(&result, "🎞️ Keyframes:", "150", css.Keyframes, 10)
+	}
+
+	// Messages, enums and services of Protocol Buffers files
+	if summary.Proto != nil {
+		proto := summary.Proto
+		result.WriteString(fmt.Sprintf("Syntax: %s • Messages: %d • Enums: %d • Services: %d\n\n",
+			proto.Syntax, len(proto.Messages), len(proto.Enums), len(proto.Services)))
+
+		m.writeSection(&result, "🛠️ Options:", "245", proto.Options, 10)
+		m.writeDefinitions(&result, "🛰️ Services:", "213", proto.Services, 10, 15)
+		m.writeDefinitions(&result, "✉️ Messages:", "39", proto.Messages, 20, 12)
+		m.writeDefinitions(&result, "🔢 Enums:", "150", proto.Enums, 10, 10)
+	}
+
+	// Type system and operations of GraphQL documents
+	if summary.GraphQL != nil {
+		graphql := summary.GraphQL
+		var counts []string
+		for _, count := range []struct {
+			label string
+			n     int
+		}{
+			{"Types", len(graphql.Types)}, {"Inputs", len(graphql.Inputs)}, {"Interfaces", len(graphql.Interfaces)},
+			{"Enums", len(graphql.Enums)}, {"Unions", len(graphql.Unions)},
+			{"Operations", len(graphql.Operations)}, {"Fragments", len(graphql.Fragments)},
+		} {
+			if count.n > 0 {
+				counts = append(counts, fmt.Sprintf("%s: %d", count.label, count.n))
+			}
+		}
+		if len(counts) > 0 {
+			result.WriteString(strings.Join(counts, " • ") + "\n\n")
+		}
+
+		m.writeDefinitions(&result, "🌱 Root Fields:", "213", graphql.RootFields, 3, 20)
+		m.writeSection(&result, "🧱 Types:", "39", graphql.Types, 20)
+		m.writeSection(&result, "🔌 Interfaces:", "81", graphql.Interfaces, 10)
+		m.writeSection(&result, "📥 Inputs:", "114", graphql.Inputs, 15)
+		m.writeSection(&result, "🔢 Enums:", "150", graphql.Enums, 15)
+		m.writeSection(&result, "🔀 Unions:", "176", graphql.Unions, 10)
+		m.writeSection(&result, "🔣 Scalars:", "245", graphql.Scalars, 10)
+		m.writeSection(&result, "❓ Operations:", "213", graphql.Operations, 20)
+		m.writeSection(&result, "🧩 Fragments:", "99", graphql.Fragments, 15)
+		m.writeSection(&result, "🏷️ Directives:", "214", graphql.Directives, 10)
+		m.writeSection(&result, "📍 Directives Used:", "180", graphql.DirectiveUses, 10)
+	}
+
+	// Terraform and HCL blocks, for a file or a whole module directory
+	if summary.Terraform != nil {
+		terraform := summary.Terraform
+		var settings []string
+		if terraform.RequiredVersion != "" {
+			settings = append(settings, "Terraform: "+terraform.RequiredVersion)
+		}
+		if terraform.Backend != "" {
+			settings = append(settings, "Backend: "+terraform.Backend)
+		}
+		if len(settings) > 0 {
+			result.WriteString(strings.Join(settings, " • ") + "\n")
+		}
+		resources := 0
+		for _, resourceType := range terraform.Resources {
+			resources += len(resourceType.Entries)
+		}
+		var counts []string
+		for _, count := range []struct {
+			label string
+			n     int
+		}{
+			{"Resources", resources}, {"Data sources", len(terraform.DataSources)}, {"Modules", len(terraform.Modules)},
+			{"Variables", len(terraform.Variables)}, {"Outputs", len(terraform.Outputs)},
+		} {
+			if count.n > 0 {
+				counts = append(counts, fmt.Sprintf("%s: %d", count.label, count.n))
+			}
+		}
+		if len(counts) > 0 {
+			result.WriteString(strings.Join(counts, " • ") + "\n")
+		}
+		result.WriteString("\n")
+
+		m.writeSection(&result, "🗂️ Files:", "245", terraform.Files, 20)
+		m.writeSection(&result, "🔌 Providers:", "99", terraform.Providers, 10)
+		m.writeDefinitions(&result, "🏗️ Resources:", "39", terraform.Resources, 20, 10)
+		m.writeSection(&result, "📖 Data Sources:", "81", terraform.DataSources, 15)
+		m.writeSection(&result, "📦 Modules:", "213", terraform.Modules, 15)
+		m.writeSection(&result, "🎚️ Variables:", "214", terraform.Variables, 20)
+		m.writeSection(&result, "📤 Outputs:", "114", terraform.Outputs, 15)
+		m.writeSection(&result, "🧮 Locals:", "180", terraform.Locals, 15)
+		m.writeSection(&result, "🧱 Blocks:", "176", terraform.Blocks, 15)
+	}
+
+	// Kernel, cell counts, outputs and errors of Jupyter notebooks
+	if summary.Notebook != nil {
+		notebook := summary.Notebook
+		var settings []string
+		if notebook.Kernel != "" {
+			settings = append(settings, "Kernel: "+notebook.Kernel)
+		}
+		if notebook.Language != "" {
+			settings = append(settings, "Language: "+notebook.Language)
+		}
+		settings = append(settings, "nbformat "+notebook.Format)
+		result.WriteString(strings.Join(settings, " • ") + "\n")
+		var counts []string
+		for _, count := range []struct {
+			label string
+			n     int
+		}{
+			{"Code cells", notebook.CodeCells}, {"Markdown cells", notebook.MarkdownCells}, {"Raw cells", notebook.RawCells},
+			{"Executed", notebook.Executed}, {"With outputs", len(notebook.Outputs)}, {"Errors", len(notebook.Errors)},
+		} {
+			if count.n > 0 {
+				counts = append(counts, fmt.Sprintf("%s: %d", count.label, count.n))
+			}
+		}
+		if len(counts) > 0 {
+			result.WriteString(strings.Join(counts, " • ") + "\n")
+		}
+		result.WriteString("\n")
+
+		m.writeSection(&result, "❌ Errors:", "196", notebook.Errors, 10)
+		m.writeSection(&result, "📤 Outputs:", "114", notebook.Outputs, 15)
+	}
+
+	// Keys of each document in a multi-document file
+	for i, document := range summary.Documents {
+		if i == 10 { // Show max 10 documents
+			result.WriteString(fmt.Sprintf("... and %d more documents\n\n", len(summary.Documents)-10))
+			break
+		}
+		m.writeSection(&result, fmt.Sprintf("📄 %s:", document.Title), "214", document.Keys, 8)
+	}
+
 	// Functions section
 	if len(summary.Functions) > 0 {
 		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true).Render("🔧 Functions:"))
@@ -291,8 +764,45 @@ func (m SummaryModel) formatSummaryForDisplay(summary core.FileSummary) string {
 		if len(summary.Structs) > 10 {
 			result.WriteString(fmt.Sprintf("  ... and %d more\n", len(summary.Structs)-10))
 		}
+		result.WriteString("\n")
 	}
 
+	m.writeSection(&result, "📜 Prototypes (declarations):", "110", summary.Prototypes, 15)
+	m.writeSection(&result, "🔣 Macros:", "173", summary.Macros, 10)
+	m.writeSection(&result, "🔌 Interfaces:", "45", summary.Interfaces, 10)
+
+	// Methods grouped by the type that owns them
+	if len(summary.Members) > 0 {
+		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render("🧩 Methods:"))
+		result.WriteString("\n")
+
+		owners := make([]string, 0, len(summary.Members))
+		for owner := range summary.Members {
+			owners = append(owners, owner)
+		}
+		sort.Strings(owners)
+
+		for _, owner := range owners {
+			result.WriteString(fmt.Sprintf("  %s\n", owner))
+			members := summary.Members[owner]
+			for i, member := range members {
+				if i < 10 { // Show max 10 members per type
+					result.WriteString(fmt.Sprintf("    • %s\n", member))
+				}
+			}
+			if len(members) > 10 {
+				result.WriteString(fmt.Sprintf("    ... and %d more\n", len(members)-10))
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	m.writeSection(&result, "🔖 Annotations:", "176", summary.Annotations, 10)
+	m.writeSection(&result, "🔒 Constants:", "178", summary.Constants, 10)
+	m.writeSection(&result, "📌 Variables:", "180", summary.Variables, 10)
+	m.writeSection(&result, "🚩 Flags:", "203", summary.Flags, 15)
+	m.writeSection(&result, "▶️ Commands:", "114", summary.Commands, 15)
+
 	// Links for markdown files
 	if len(summary.Links) > 0 {
 		result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render("🔗 Links:"))
@@ -307,9 +817,143 @@ func (m SummaryModel) formatSummaryForDisplay(summary core.FileSummary) string {
 		}
 	}
 
+	// Cell-by-cell preview of notebooks
+	if summary.Notebook != nil && len(summary.Notebook.Cells) > 0 {
+		m.writeNotebookCells(&result, summary.Notebook)
+	}
+
 	return result.String()
 }
 
+// writeNotebookCells renders each notebook cell with its first source lines
+// and a line per output
+func (m *SummaryModel) writeNotebookCells(result *strings.Builder, notebook *core.NotebookSummary) {
+	result.WriteString("\n")
+	result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true).Render("📓 Cells:"))
+	result.WriteString("\n")
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	for _, cell := range notebook.Cells {
+		header := fmt.Sprintf("── [%d] %s", cell.Index, cell.Kind)
+		if cell.ExecutionCount > 0 {
+			header += fmt.Sprintf(" (In [%d])", cell.ExecutionCount)
+		}
+		result.WriteString(headerStyle.Render(header) + "\n")
+		for _, line := range cell.Source {
+			result.WriteString("    " + line + "\n")
+		}
+		if cell.MoreLines > 0 {
+			result.WriteString(fmt.Sprintf("    ... %d more lines\n", cell.MoreLines))
+		}
+		for _, output := range cell.Outputs {
+			if strings.HasPrefix(output, "✗") {
+				result.WriteString("  " + errorStyle.Render(output) + "\n")
+			} else {
+				result.WriteString("  → " + output + "\n")
+			}
+		}
+	}
+	if total := notebook.CodeCells + notebook.MarkdownCells + notebook.RawCells; total > len(notebook.Cells) {
+		result.WriteString(fmt.Sprintf("... and %d more cells\n", total-len(notebook.Cells)))
+	}
+}
+
+// formatTable aligns rows into columns, truncating cells wider than maxWidth
+// and underlining the header row
+func formatTable(rows [][]string, hasHeader bool, maxWidth int) []string {
+	var widths []int
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		for j, cell := range row {
+			cell = strings.ReplaceAll(strings.TrimSpace(cell), "\n", " ")
+			if lipgloss.Width(cell) > maxWidth {
+				runes := []rune(cell)
+				if len(runes) > maxWidth {
+					runes = runes[:maxWidth]
+				}
+				for lipgloss.Width(string(runes)) > maxWidth-1 {
+					runes = runes[:len(runes)-1]
+				}
+				cell = string(runes) + "…"
+			}
+			cells[i] = append(cells[i], cell)
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if width := lipgloss.Width(cell); width > widths[j] {
+				widths[j] = width
+			}
+		}
+	}
+
+	var lines []string
+	for i, row := range cells {
+		var line strings.Builder
+		for j, cell := range row {
+			if j > 0 {
+				line.WriteString(" │ ")
+			}
+			line.WriteString(cell + strings.Repeat(" ", widths[j]-lipgloss.Width(cell)))
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+
+		if i == 0 && hasHeader {
+			var separator []string
+			for _, width := range widths {
+				separator = append(separator, strings.Repeat("─", width))
+			}
+			lines = append(lines, strings.Join(separator, "─┼─"))
+		}
+	}
+	return lines
+}
+
+// writeSection renders a titled bullet list, showing at most limit items
+func (m SummaryModel) writeSection(result *strings.Builder, title, color string, items []string, limit int) {
+	if len(items) == 0 {
+		return
+	}
+
+	result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(title))
+	result.WriteString("\n")
+	for i, item := range items {
+		if i < limit {
+			result.WriteString(fmt.Sprintf("  • %s\n", item))
+		}
+	}
+	if len(items) > limit {
+		result.WriteString(fmt.Sprintf("  ... and %d more\n", len(items)-limit))
+	}
+	result.WriteString("\n")
+}
+
+// writeDefinitions writes a titled list of definitions, each followed by its
+// entries, with per-definition and per-entry limits
+func (m SummaryModel) writeDefinitions(result *strings.Builder, title, color string, definitions []core.Definition, limit, entryLimit int) {
+	if len(definitions) == 0 {
+		return
+	}
+
+	result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(title))
+	result.WriteString("\n")
+	for i, definition := range definitions {
+		if i == limit {
+			result.WriteString(fmt.Sprintf("  ... and %d more\n", len(definitions)-limit))
+			break
+		}
+		result.WriteString(fmt.Sprintf("  %s\n", definition.Name))
+		for j, entry := range definition.Entries {
+			if j < entryLimit {
+				result.WriteString(fmt.Sprintf("    • %s\n", entry))
+			}
+		}
+		if len(definition.Entries) > entryLimit {
+			result.WriteString(fmt.Sprintf("    ... and %d more\n", len(definition.Entries)-entryLimit))
+		}
+	}
+	result.WriteString("\n")
+}
+
 // GetScrollInfo returns current scroll information
 func (m SummaryModel) GetScrollInfo() (current, maxScroll int) {
 	lines := strings.Split(m.content, "\n")
@@ -5,61 +5,34 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 
-// SupportedExtensions defines the file extensions we support for summarization
-var SupportedExtensions = map[string]bool{
-	// Programming languages
-	".go":    true,
-	".py":    true,
-	".rs":    true,
-	".js":    true,
-	".ts":    true,
-	".jsx":   true,
-	".tsx":   true,
-	".java":  true,
-	".c":     true,
-	".cpp":   true,
-	".cc":    true,
-	".h":     true,
-	".hpp":   true,
-	".cs":    true,
-	".php":   true,
-	".rb":    true,
-	".swift": true,
-	".kt":    true,
-	".scala": true,
-
-	// Markup and documentation
-	".md":       true,
-	".markdown": true,
-	".txt":      true,
-	".rst":      true,
-
-	// Configuration files
-	".json":       true,
-	".yaml":       true,
-	".yml":        true,
-	".toml":       true,
-	".ini":        true,
-	".cfg":        true,
-	".conf":       true,
-	".env":        true,
-	".properties": true,
-
-	// Data files
-	".xml": true,
-	".csv": true,
-	".log": true,
-
-	// Shell and scripts
-	".sh":   true,
-	".bash": true,
-	".zsh":  true,
-	".fish": true,
-	".ps1":  true,
-	".bat":  true,
-	".cmd":  true,
+// supportedExtensions holds the file extensions we support for summarization.
+// It is populated by the parser registrations in the core package through
+// AddSupportedExtension and read through IsSourceFile.
+var supportedExtensions = make(map[string]bool)
+
+// supportedFilenames holds lower-cased file names we support regardless of
+// extension, such as "makefile". It is populated alongside supportedExtensions.
+var supportedFilenames = make(map[string]bool)
+
+// supportedMu guards supportedExtensions and supportedFilenames, since parsers
+// may be registered while the file list is already being rendered
+var supportedMu sync.RWMutex
+
+// AddSupportedExtension marks a lower-cased extension, including the dot, as supported
+func AddSupportedExtension(ext string) {
+	supportedMu.Lock()
+	defer supportedMu.Unlock()
+	supportedExtensions[ext] = true
+}
+
+// AddSupportedFilename marks a lower-cased file name as supported
+func AddSupportedFilename(name string) {
+	supportedMu.Lock()
+	defer supportedMu.Unlock()
+	supportedFilenames[name] = true
 }
 
 // FileInfo represents basic information about a discovered file
@@ -146,10 +119,16 @@ func (w *Walker) ListDirectory(dirPath string) ([]FileInfo, error) {
 	return w.files, nil
 }
 
-// IsSourceFile checks if a file has a supported extension
+// IsSourceFile checks if a file has a supported name or extension
 func IsSourceFile(filename string) bool {
+	supportedMu.RLock()
+	defer supportedMu.RUnlock()
+
+	if supportedFilenames[strings.ToLower(filepath.Base(filename))] {
+		return true
+	}
 	ext := strings.ToLower(filepath.Ext(filename))
-	return SupportedExtensions[ext]
+	return supportedExtensions[ext]
 }
 
 // IsExecutableFile checks if a file is executable
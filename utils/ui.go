@@ -1,102 +1,133 @@
 package utils
 
-// GetFileIcon returns an appropriate icon for the file extension
-func GetFileIcon(ext string) string {
-	icons := map[string]string{
-		// Programming languages
-		".go":    "🐹",
-		".py":    "🐍",
-		".js":    "📄",
-		".ts":    "📘",
-		".jsx":   "⚛️",
-		".tsx":   "⚛️",
-		".rs":    "🦀",
-		".java":  "☕",
-		".c":     "📄",
-		".cpp":   "📄",
-		".cc":    "📄",
-		".h":     "📄",
-		".hpp":   "📄",
-		".cs":    "🔷",
-		".php":   "🐘",
-		".rb":    "💎",
-		".swift": "🍎",
-		".kt":    "📱",
-		".scala": "⚖️",
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+)
 
-		// Documentation and markup
-		".md":       "📝",
-		".markdown": "📝",
-		".txt":      "📄",
-		".rst":      "📜",
-		".tex":      "📰",
+// fileIconsMu guards fileIcons against registrations made after startup
+var fileIconsMu sync.RWMutex
 
-		// Configuration files
-		".json":       "🔧",
-		".yaml":       "⚙️",
-		".yml":        "⚙️",
-		".toml":       "⚙️",
-		".ini":        "⚙️",
-		".cfg":        "⚙️",
-		".conf":       "⚙️",
-		".env":        "🌿",
-		".properties": "⚙️",
+// fileIcons maps file extensions and lower-cased file names to icons
+var fileIcons = map[string]string{
+	// Programming languages
+	".go":    "🐹",
+	".py":    "🐍",
+	".js":    "📄",
+	".ts":    "📘",
+	".jsx":   "⚛️",
+	".tsx":   "⚛️",
+	".rs":    "🦀",
+	".java":  "☕",
+	".c":     "📄",
+	".cpp":   "📄",
+	".cc":    "📄",
+	".h":     "📄",
+	".hpp":   "📄",
+	".cs":    "🔷",
+	".php":   "🐘",
+	".rb":    "💎",
+	".swift": "🍎",
+	".kt":    "📱",
+	".scala": "⚖️",
 
-		// Data files
-		".xml": "📋",
-		".csv": "📊",
-		".log": "📜",
-		".sql": "🗄️",
+	// Documentation and markup
+	".md":       "📝",
+	".markdown": "📝",
+	".txt":      "📄",
+	".rst":      "📜",
+	".tex":      "📰",
 
-		// Shell and scripts
-		".sh":   "🐚",
-		".bash": "🐚",
-		".zsh":  "🐚",
-		".fish": "🐠",
-		".ps1":  "💻",
-		".bat":  "💻",
-		".cmd":  "💻",
+	// Configuration files
+	".json":       "🔧",
+	".yaml":       "⚙️",
+	".yml":        "⚙️",
+	".toml":       "⚙️",
+	".ini":        "⚙️",
+	".cfg":        "⚙️",
+	".conf":       "⚙️",
+	".env":        "🌿",
+	".properties": "⚙️",
 
-		// Build and package files
-		".dockerfile": "🐳",
-		".makefile":   "🔨",
-		".gradle":     "🐘",
-		".pom":        "📦",
-		".package":    "📦",
+	// Data files
+	".xml": "📋",
+	".csv": "📊",
+	".log": "📜",
+	".sql": "🗄️",
 
-		// Web and frontend
-		".html": "🌐",
-		".htm":  "🌐",
-		".css":  "🎨",
-		".scss": "🎨",
-		".sass": "🎨",
-		".less": "🎨",
+	// Shell and scripts
+	".sh":   "🐚",
+	".bash": "🐚",
+	".zsh":  "🐚",
+	".fish": "🐠",
+	".ps1":  "💻",
+	".bat":  "💻",
+	".cmd":  "💻",
 
-		// Images
-		".png":  "🖼️",
-		".jpg":  "🖼️",
-		".jpeg": "🖼️",
-		".gif":  "🖼️",
-		".svg":  "🖼️",
-		".ico":  "🖼️",
+	// Build and package files
+	".dockerfile": "🐳",
+	".makefile":   "🔨",
+	".gradle":     "🐘",
+	".pom":        "📦",
+	".package":    "📦",
 
-		// Archives
-		".zip": "📦",
-		".tar": "📦",
-		".gz":  "📦",
-		".rar": "📦",
-		".7z":  "📦",
+	// Web and frontend
+	".html": "🌐",
+	".htm":  "🌐",
+	".css":  "🎨",
+	".scss": "🎨",
+	".sass": "🎨",
+	".less": "🎨",
 
-		// Executables
-		".exe": "⚙️",
-		".bin": "⚙️",
-		".deb": "📦",
-		".rpm": "📦",
-		".msi": "📦",
-	}
+	// Images
+	".png":  "🖼️",
+	".jpg":  "🖼️",
+	".jpeg": "🖼️",
+	".gif":  "🖼️",
+	".svg":  "🖼️",
+	".ico":  "🖼️",
+
+	// Archives
+	".zip": "📦",
+	".tar": "📦",
+	".gz":  "📦",
+	".rar": "📦",
+	".7z":  "📦",
+
+	// Executables
+	".exe": "⚙️",
+	".bin": "⚙️",
+	".deb": "📦",
+	".rpm": "📦",
+	".msi": "📦",
+}
+
+// RegisterFileIcon sets the icon for a file extension or lower-cased file name
+func RegisterFileIcon(key, icon string) {
+	fileIconsMu.Lock()
+	defer fileIconsMu.Unlock()
+	fileIcons[key] = icon
+}
 
-	if icon, exists := icons[ext]; exists {
+// GetFileIcon returns an appropriate icon for the file extension
+func GetFileIcon(ext string) string {
+	fileIconsMu.RLock()
+	defer fileIconsMu.RUnlock()
+	if icon, exists := fileIcons[ext]; exists {
 		return icon
 	}
 	return "📄"
 }
+
+// GetFileIconForName returns an icon for a file, checking its full name
+// before falling back to the extension
+func GetFileIconForName(name string) string {
+	fileIconsMu.RLock()
+	icon, exists := fileIcons[strings.ToLower(filepath.Base(name))]
+	fileIconsMu.RUnlock()
+	if exists {
+		return icon
+	}
+	return GetFileIcon(strings.ToLower(filepath.Ext(name)))
+}